@@ -0,0 +1,497 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// reservedNames may not be used as generated parameter names because the
+// generated code already uses them as identifiers or package names.
+var reservedNames = map[string]bool{
+	"ctx": true, "signer": true, "opts": true, "i": true, "out": true, "err": true,
+	"abi": true, "big": true, "common": true, "context": true, "contract": true,
+	"goethTypes": true, "listener": true, "provider": true, "scanner": true,
+	"strings": true, "types": true, "ethereum": true, "fmt": true,
+}
+
+// contractMethods are promoted from the embedded contract.Contract.
+var contractMethods = map[string]bool{
+	"Call": true, "CallWithOpts": true, "CallInto": true, "CallIntoWithOpts": true,
+	"Transact": true, "TransactWithSigner": true, "ReplayTransaction": true, "ABI": true,
+}
+
+type param struct {
+	// Name is the Go parameter name.
+	Name string
+	// Field is the Go struct field name.
+	Field string
+	// Tag is the ABI name used in the abi struct tag, empty for unnamed values.
+	Tag  string
+	Type string
+	// Arg is the expression handed to the ABI packer.
+	Arg string
+}
+
+type method struct {
+	Name      string
+	Key       string
+	Signature string
+	Inputs    []param
+	Outputs   []param
+	// Result is the return type of a view.
+	Result string
+	// ResultStruct is set when Result is a generated struct for several outputs.
+	ResultStruct bool
+}
+
+type event struct {
+	Name      string
+	Key       string
+	Signature string
+	Fields    []param
+}
+
+type customError struct {
+	Name      string
+	Key       string
+	Signature string
+	Fields    []param
+}
+
+type structDef struct {
+	Name   string
+	Fields []param
+}
+
+type model struct {
+	Package     string
+	Type        string
+	ABI         string
+	Views       []*method
+	Transactors []*method
+	Events      []*event
+	Errors      []*customError
+	Structs     []*structDef
+
+	structs       map[string]*structDef
+	needsAddrList bool
+}
+
+func (m *model) NeedsAddrList() bool {
+	return m.needsAddrList
+}
+
+// knownImports are the packages generated code may refer to, by selector.
+var knownImports = []struct {
+	selector string
+	path     string
+}{
+	{"context", "context"},
+	{"fmt", "fmt"},
+	{"big", "math/big"},
+	{"strings", "strings"},
+	{"", ""},
+	{"contract", "github.com/dtome123/go-bcwe3/eth/contract"},
+	{"listener", "github.com/dtome123/go-bcwe3/eth/listener"},
+	{"provider", "github.com/dtome123/go-bcwe3/eth/provider"},
+	{"scanner", "github.com/dtome123/go-bcwe3/eth/scanner"},
+	{"signer", "github.com/dtome123/go-bcwe3/eth/signer"},
+	{"types", "github.com/dtome123/go-bcwe3/eth/types"},
+	{"ethereum", "github.com/ethereum/go-ethereum"},
+	{"abi", "github.com/ethereum/go-ethereum/accounts/abi"},
+	{"common", "github.com/ethereum/go-ethereum/common"},
+	{"goethTypes", "goethTypes github.com/ethereum/go-ethereum/core/types"},
+}
+
+// withImports prepends the package clause and the imports body refers to.
+func withImports(pkg string, body []byte) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by bcwe3gen. DO NOT EDIT.\n\npackage %s\n\n", pkg)
+
+	var lines []string
+	for _, imp := range knownImports {
+		if imp.selector == "" {
+			lines = append(lines, "")
+			continue
+		}
+		if regexp.MustCompile(`(^|[^A-Za-z0-9_.])` + imp.selector + `\.`).Match(body) {
+			path := imp.path
+			if !strings.Contains(path, " ") {
+				path = strconv.Quote(path)
+			} else {
+				alias, rest, _ := strings.Cut(path, " ")
+				path = alias + " " + strconv.Quote(rest)
+			}
+			lines = append(lines, "\t"+path)
+		}
+	}
+
+	if strings.TrimSpace(strings.Join(lines, "")) != "" {
+		b.WriteString("import (\n")
+		b.WriteString(strings.Trim(strings.Join(lines, "\n"), "\n"))
+		b.WriteString("\n)\n\n")
+	}
+
+	b.Write(body)
+	return b.Bytes()
+}
+
+// Generate returns the files of a Go package binding the ABI in data.
+func Generate(data []byte, pkg string, typeName string) (map[string][]byte, error) {
+	rawABI, err := extractABI(data)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := abi.JSON(bytes.NewReader(rawABI))
+	if err != nil {
+		return nil, fmt.Errorf("parse ABI: %w", err)
+	}
+
+	if typeName == "" {
+		typeName = abi.ToCamelCase(pkg)
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, rawABI, "", "\t"); err != nil {
+		return nil, err
+	}
+
+	m := &model{
+		Package: pkg,
+		Type:    typeName,
+		ABI:     strings.ReplaceAll(indented.String(), "`", "` + \"`\" + `"),
+		structs: make(map[string]*structDef),
+	}
+
+	if err := m.build(parsed); err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	for name, tmpl := range map[string]string{
+		"abi.go":  abiTemplate,
+		"spec.go": specTemplate,
+		"impl.go": implTemplate,
+	} {
+		t, err := template.New(name).Parse(tmpl)
+		if err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, m); err != nil {
+			return nil, fmt.Errorf("render %s: %w", name, err)
+		}
+
+		body := buf.Bytes()
+		if name != "abi.go" {
+			body = withImports(pkg, body)
+		} else {
+			body = append([]byte("// Code generated by bcwe3gen. DO NOT EDIT.\n\npackage "+pkg+"\n\n"), body...)
+		}
+
+		src, err := format.Source(body)
+		if err != nil {
+			return nil, fmt.Errorf("format %s: %w\n%s", name, err, body)
+		}
+		files[name] = src
+	}
+
+	return files, nil
+}
+
+// extractABI accepts a plain ABI array or an artifact object with an "abi" field.
+func extractABI(data []byte) ([]byte, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var artifact struct {
+			ABI json.RawMessage `json:"abi"`
+		}
+		if err := json.Unmarshal(trimmed, &artifact); err != nil {
+			return nil, err
+		}
+		if len(artifact.ABI) == 0 {
+			return nil, fmt.Errorf("artifact has no abi field")
+		}
+		return artifact.ABI, nil
+	}
+	return trimmed, nil
+}
+
+func (m *model) build(parsed abi.ABI) error {
+	used := make(map[string]bool)
+	for name := range contractMethods {
+		used[name] = true
+	}
+
+	for _, key := range sortedKeys(parsed.Methods) {
+		abiMethod := parsed.Methods[key]
+		var name string
+		if abiMethod.IsConstant() {
+			name = viewName(abi.ToCamelCase(key), used)
+		} else {
+			name = uniqueName(abi.ToCamelCase(key), used)
+		}
+
+		meth := &method{
+			Name:      name,
+			Key:       key,
+			Signature: abiMethod.Sig,
+		}
+
+		for i, input := range abiMethod.Inputs {
+			meth.Inputs = append(meth.Inputs, m.param(input, i, name, false))
+		}
+		dedupeParams(meth.Inputs)
+
+		if abiMethod.IsConstant() {
+			for i, output := range abiMethod.Outputs {
+				meth.Outputs = append(meth.Outputs, m.param(output, i, name, true))
+			}
+
+			switch len(meth.Outputs) {
+			case 0:
+				meth.Result = ""
+			case 1:
+				meth.Result = meth.Outputs[0].Type
+			default:
+				meth.Result = name + "Result"
+				meth.ResultStruct = true
+			}
+			m.Views = append(m.Views, meth)
+		} else {
+			m.Transactors = append(m.Transactors, meth)
+		}
+	}
+
+	for _, key := range sortedKeys(parsed.Events) {
+		abiEvent := parsed.Events[key]
+		ev := &event{
+			Name:      m.eventName(abi.ToCamelCase(key), used),
+			Key:       key,
+			Signature: abiEvent.Sig,
+		}
+		for i, input := range abiEvent.Inputs {
+			p := m.param(input, i, ev.Name, true)
+			if input.Indexed && isDynamic(input.Type) {
+				p.Type = "common.Hash"
+			}
+			ev.Fields = append(ev.Fields, p)
+		}
+		m.Events = append(m.Events, ev)
+	}
+
+	for _, key := range sortedKeys(parsed.Errors) {
+		abiErr := parsed.Errors[key]
+		ce := &customError{
+			Name:      abi.ToCamelCase(key),
+			Key:       key,
+			Signature: abiErr.Sig,
+		}
+		for i, input := range abiErr.Inputs {
+			ce.Fields = append(ce.Fields, m.param(input, i, ce.Name, true))
+		}
+		m.Errors = append(m.Errors, ce)
+	}
+
+	sort.Slice(m.Structs, func(a, b int) bool { return m.Structs[a].Name < m.Structs[b].Name })
+
+	return nil
+}
+
+// param maps an ABI argument to a Go parameter. Top-level addresses use the
+// library's string addresses; values inside tuples keep go-ethereum types.
+func (m *model) param(arg abi.Argument, index int, context string, output bool) param {
+	field := abi.ToCamelCase(arg.Name)
+	if arg.Name == "" {
+		field = fmt.Sprintf("Arg%d", index)
+	}
+
+	name := lowerFirst(field)
+	if token.IsKeyword(name) || reservedNames[name] {
+		name += "Arg"
+	}
+
+	p := param{
+		Name:  name,
+		Field: field,
+		Tag:   arg.Name,
+		Type:  m.goType(arg.Type, false, context+field),
+	}
+
+	switch {
+	case arg.Type.T == abi.AddressTy:
+		p.Arg = "common.HexToAddress(" + name + ")"
+	case arg.Type.T == abi.SliceTy && arg.Type.Elem.T == abi.AddressTy:
+		p.Arg = "toAddresses(" + name + ")"
+		m.needsAddrList = m.needsAddrList || !output
+	default:
+		p.Arg = name
+	}
+
+	return p
+}
+
+func (m *model) goType(t abi.Type, native bool, context string) string {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		prefix := "int"
+		if t.T == abi.UintTy {
+			prefix = "uint"
+		}
+		switch t.Size {
+		case 8, 16, 32, 64:
+			return fmt.Sprintf("%s%d", prefix, t.Size)
+		}
+		return "*big.Int"
+	case abi.BoolTy:
+		return "bool"
+	case abi.StringTy:
+		return "string"
+	case abi.BytesTy:
+		return "[]byte"
+	case abi.FixedBytesTy:
+		return fmt.Sprintf("[%d]byte", t.Size)
+	case abi.FunctionTy:
+		return "[24]byte"
+	case abi.HashTy:
+		return "common.Hash"
+	case abi.AddressTy:
+		if native {
+			return "common.Address"
+		}
+		return "string"
+	case abi.SliceTy:
+		if t.Elem.T == abi.AddressTy && !native {
+			return "[]string"
+		}
+		return "[]" + m.goType(*t.Elem, true, context)
+	case abi.ArrayTy:
+		return fmt.Sprintf("[%d]%s", t.Size, m.goType(*t.Elem, true, context))
+	case abi.TupleTy:
+		return m.tupleStruct(t, context)
+	}
+
+	return "any"
+}
+
+func (m *model) tupleStruct(t abi.Type, context string) string {
+	name := abi.ToCamelCase(t.TupleRawName)
+	if name == "" {
+		name = context
+	}
+
+	if _, ok := m.structs[name]; ok {
+		return name
+	}
+
+	def := &structDef{Name: name}
+	m.structs[name] = def
+
+	for i, elem := range t.TupleElems {
+		field := abi.ToCamelCase(t.TupleRawNames[i])
+		def.Fields = append(def.Fields, param{
+			Field: field,
+			Tag:   t.TupleRawNames[i],
+			Type:  m.goType(*elem, true, name+field),
+		})
+	}
+	m.Structs = append(m.Structs, def)
+
+	return name
+}
+
+func isDynamic(t abi.Type) bool {
+	switch t.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return true
+	}
+	return false
+}
+
+// eventPrefixes name the interface methods generated for every event.
+var eventPrefixes = []string{"Filter", "Watch", "Unpack"}
+
+// eventName picks a name whose generated identifiers (<Name>Event and the
+// Filter, Watch and Unpack methods) collide with nothing generated so far.
+func (m *model) eventName(name string, used map[string]bool) string {
+	taken := func(candidate string) bool {
+		if _, ok := m.structs[candidate+"Event"]; ok || candidate+"Event" == m.Type {
+			return true
+		}
+		for _, view := range m.Views {
+			if view.Result == candidate+"Event" {
+				return true
+			}
+		}
+		for _, prefix := range eventPrefixes {
+			if used[prefix+candidate] {
+				return true
+			}
+		}
+		return false
+	}
+
+	candidate := name
+	for i := 0; taken(candidate); i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	for _, prefix := range eventPrefixes {
+		used[prefix+candidate] = true
+	}
+	return candidate
+}
+
+func uniqueName(name string, used map[string]bool) string {
+	candidate := name
+	for i := 0; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// viewName is uniqueName for views, which also generate <name>At.
+func viewName(name string, used map[string]bool) string {
+	candidate := name
+	for i := 0; used[candidate] || used[candidate+"At"]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	used[candidate] = true
+	used[candidate+"At"] = true
+	return candidate
+}
+
+func dedupeParams(params []param) {
+	seen := make(map[string]bool)
+	for i := range params {
+		params[i].Name = uniqueName(params[i].Name, seen)
+	}
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// TestGenerateGolden compares the generated files for every testdata/<name>.json
+// ABI with testdata/<name>/<file>.golden. Run with -update after intended changes.
+func TestGenerateGolden(t *testing.T) {
+	abis, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(abis) == 0 {
+		t.Fatal("no ABIs in testdata")
+	}
+
+	for _, abiPath := range abis {
+		pkg := strings.TrimSuffix(filepath.Base(abiPath), ".json")
+
+		t.Run(pkg, func(t *testing.T) {
+			data, err := os.ReadFile(abiPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			files, err := Generate(data, pkg, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dir := filepath.Join("testdata", pkg)
+			if *update {
+				if err := os.MkdirAll(dir, 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for name, got := range files {
+				golden := filepath.Join(dir, name+".golden")
+
+				if *update {
+					if err := os.WriteFile(golden, got, 0o644); err != nil {
+						t.Fatal(err)
+					}
+					continue
+				}
+
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("%s differs from %s; run go test -update to accept\n%s", name, golden, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Command bcwe3gen generates a typed Go package for a contract ABI in the
+// style of the eth/erc20, eth/erc721 and eth/erc1155 wrappers.
+//
+//	bcwe3gen -abi Vault.json -pkg vault -type Vault -out ./vault
+//
+// The ABI file may be a plain ABI array or a compiler artifact with an "abi"
+// field. The generated package exposes New(address, provider) returning an
+// interface with typed views, signer-based transactors, Filter/Watch/Unpack
+// functions per event and an As<Name>Error helper per custom error.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	abiPath := flag.String("abi", "", "path to the ABI JSON or compiler artifact")
+	pkg := flag.String("pkg", "", "Go package name")
+	typeName := flag.String("type", "", "name of the generated interface (defaults to the package name in CamelCase)")
+	out := flag.String("out", ".", "output directory")
+	flag.Parse()
+
+	if *abiPath == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*abiPath, *pkg, *typeName, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "bcwe3gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(abiPath, pkg, typeName, out string) error {
+	data, err := os.ReadFile(abiPath)
+	if err != nil {
+		return err
+	}
+
+	files, err := Generate(data, pkg, typeName)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return err
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(out, name), content, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package main
+
+const abiTemplate = `// ABI is the contract ABI the bindings were generated from.
+const ABI = ` + "`" + `
+{{.ABI}}
+` + "`" + `
+`
+
+const specTemplate = `type {{.Type}} interface {
+	contract.Contract
+{{range .Views}}
+	// {{.Name}} calls {{.Signature}}.
+	{{.Name}}(ctx context.Context{{range .Inputs}}, {{.Name}} {{.Type}}{{end}}) ({{if .Result}}{{.Result}}, {{end}}error)
+	// {{.Name}}At calls {{.Signature}} with the caller and block selected by opts.
+	{{.Name}}At(ctx context.Context{{range .Inputs}}, {{.Name}} {{.Type}}{{end}}, opts *contract.CallOpts) ({{if .Result}}{{.Result}}, {{end}}error)
+{{- end}}
+{{range .Transactors}}
+	// {{.Name}} sends a transaction calling {{.Signature}}.
+	{{.Name}}(ctx context.Context, signer signer.Signer{{range .Inputs}}, {{.Name}} {{.Type}}{{end}}, opts *contract.TransactOpts) (*types.Tx, error)
+{{- end}}
+{{range .Events}}
+	// Filter{{.Name}} returns the {{.Key}} events in [fromBlock, toBlock]. Nil bounds mean genesis and latest.
+	Filter{{.Name}}(ctx context.Context, fromBlock *big.Int, toBlock *big.Int) ([]*{{.Name}}Event, error)
+	// Watch{{.Name}} calls handler for every new {{.Key}} event received through l.
+	Watch{{.Name}}(ctx context.Context, l *listener.Listener, handler func(*{{.Name}}Event))
+	// Unpack{{.Name}} decodes a log into a {{.Name}}Event.
+	Unpack{{.Name}}(log goethTypes.Log) (*{{.Name}}Event, error)
+{{- end}}
+}
+{{range .Structs}}
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Field}} {{.Type}}{{if .Tag}} ` + "`" + `abi:"{{.Tag}}"` + "`" + `{{end}}
+{{- end}}
+}
+{{end}}
+{{- range .Views}}{{if .ResultStruct}}
+// {{.Result}} holds the outputs of {{.Key}}.
+type {{.Result}} struct {
+{{- range .Outputs}}
+	{{.Field}} {{.Type}}{{if .Tag}} ` + "`" + `abi:"{{.Tag}}"` + "`" + `{{end}}
+{{- end}}
+}
+{{end}}{{end}}
+{{- range .Events}}
+// {{.Name}}Event is the {{.Signature}} event.
+type {{.Name}}Event struct {
+{{- range .Fields}}
+	{{.Field}} {{.Type}}
+{{- end}}
+	Raw *types.Log
+}
+{{end}}
+{{- range .Errors}}
+// {{.Name}}Error is the {{.Signature}} custom error.
+type {{.Name}}Error struct {
+{{- range .Fields}}
+	{{.Field}} {{.Type}}{{if .Tag}} ` + "`" + `abi:"{{.Tag}}"` + "`" + `{{end}}
+{{- end}}
+}
+{{end}}`
+
+const implTemplate = `type impl struct {
+	contract.Contract
+	address  string
+	provider provider.Provider
+}
+{{if .Errors}}
+var parsedABI, _ = abi.JSON(strings.NewReader(ABI))
+{{end}}
+func New(address string, provider provider.Provider) ({{.Type}}, error) {
+
+	contract, err := contract.NewContract(provider, address, ABI)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &impl{
+		provider: provider,
+		address:  address,
+		Contract: contract,
+	}, nil
+}
+{{range .Views}}
+func (i *impl) {{.Name}}(ctx context.Context{{range .Inputs}}, {{.Name}} {{.Type}}{{end}}) ({{if .Result}}{{.Result}}, {{end}}error) {
+	return i.{{.Name}}At(ctx{{range .Inputs}}, {{.Name}}{{end}}, nil)
+}
+
+func (i *impl) {{.Name}}At(ctx context.Context{{range .Inputs}}, {{.Name}} {{.Type}}{{end}}, opts *contract.CallOpts) ({{if .Result}}{{.Result}}, {{end}}error) {
+{{- if .Result}}
+	var out {{.Result}}
+	err := i.Contract.CallIntoWithOpts(ctx, opts, &out, "{{.Key}}"{{range .Inputs}}, {{.Arg}}{{end}})
+	return out, err
+{{- else}}
+	_, err := i.Contract.CallWithOpts(ctx, opts, "{{.Key}}"{{range .Inputs}}, {{.Arg}}{{end}})
+	return err
+{{- end}}
+}
+{{end}}
+{{- range .Transactors}}
+func (i *impl) {{.Name}}(ctx context.Context, signer signer.Signer{{range .Inputs}}, {{.Name}} {{.Type}}{{end}}, opts *contract.TransactOpts) (*types.Tx, error) {
+	return i.Contract.TransactWithSigner(ctx, signer, opts, "{{.Key}}"{{range .Inputs}}, {{.Arg}}{{end}})
+}
+{{end}}
+{{- range .Events}}
+func (i *impl) Filter{{.Name}}(ctx context.Context, fromBlock *big.Int, toBlock *big.Int) ([]*{{.Name}}Event, error) {
+	logs, err := scanner.NewLogScanner(i.provider, nil).FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Addresses: []common.Address{common.HexToAddress(i.address)},
+		Topics:    [][]common.Hash{ {i.Contract.ABI().Events["{{.Key}}"].ID} },
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*{{.Name}}Event, 0, len(logs))
+	for _, log := range logs {
+		ev, err := i.Unpack{{.Name}}(*log.Origin)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, ev)
+	}
+
+	return events, nil
+}
+
+func (i *impl) Watch{{.Name}}(ctx context.Context, l *listener.Listener, handler func(*{{.Name}}Event)) {
+	l.ListenEvents(ctx, i.address, i.Contract.ABI(), []string{"{{.Key}}"}, func(log goethTypes.Log) {
+		ev, err := i.Unpack{{.Name}}(log)
+		if err != nil {
+			fmt.Printf("[{{$.Package}}] failed to unpack {{.Key}}: %v\n", err)
+			return
+		}
+		handler(ev)
+	})
+}
+
+func (i *impl) Unpack{{.Name}}(log goethTypes.Log) (*{{.Name}}Event, error) {
+	results, err := contract.UnpackLog(i.Contract.ABI(), "{{.Key}}", log)
+	if err != nil {
+		return nil, err
+	}
+
+	ev := &{{.Name}}Event{Raw: types.WrapLog(&log)}
+{{- range $index, $field := .Fields}}
+	if err := results.Index({{$index}}).Decode(&ev.{{$field.Field}}); err != nil {
+		return nil, err
+	}
+{{- end}}
+
+	return ev, nil
+}
+{{end}}
+{{- range .Errors}}
+func (e *{{.Name}}Error) Error() string {
+{{- if .Fields}}
+	return fmt.Sprintf("{{.Key}}({{range $index, $field := .Fields}}{{if $index}}, {{end}}{{or $field.Tag $field.Field}}=%v{{end}})"{{range .Fields}}, e.{{.Field}}{{end}})
+{{- else}}
+	return "{{.Key}}()"
+{{- end}}
+}
+
+// As{{.Name}}Error reports whether err is the {{.Key}} custom error and decodes it.
+func As{{.Name}}Error(err error) (*{{.Name}}Error, bool) {
+	var out {{.Name}}Error
+	if !contract.ErrorAs(err, parsedABI, "{{.Key}}", &out) {
+		return nil, false
+	}
+	return &out, true
+}
+{{end}}
+{{- if .NeedsAddrList}}
+func toAddresses(addresses []string) []common.Address {
+	out := make([]common.Address, len(addresses))
+	for i, address := range addresses {
+		out[i] = common.HexToAddress(address)
+	}
+	return out
+}
+{{end}}`
@@ -0,0 +1,6 @@
+package constants
+
+// Create2FactoryAddress is the deterministic deployment proxy available on most
+// EVM chains. Its calldata is a 32-byte salt followed by the init code and it
+// returns the deployed address.
+const Create2FactoryAddress = "0x4e59b44847b379578588920cA78FbF26c0B4956C"
@@ -0,0 +1,41 @@
+package constants
+
+// ERC1271MagicValue is returned by isValidSignature for a valid signature.
+var ERC1271MagicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
+
+// ERC6492MagicSuffix marks a signature wrapped for a counterfactual wallet.
+var ERC6492MagicSuffix = [32]byte{
+	0x64, 0x92, 0x64, 0x92, 0x64, 0x92, 0x64, 0x92,
+	0x64, 0x92, 0x64, 0x92, 0x64, 0x92, 0x64, 0x92,
+	0x64, 0x92, 0x64, 0x92, 0x64, 0x92, 0x64, 0x92,
+	0x64, 0x92, 0x64, 0x92, 0x64, 0x92, 0x64, 0x92,
+}
+
+const ERC1271ABI = `
+[
+	{
+		"inputs": [
+			{
+				"internalType": "bytes32",
+				"name": "hash",
+				"type": "bytes32"
+			},
+			{
+				"internalType": "bytes",
+				"name": "signature",
+				"type": "bytes"
+			}
+		],
+		"name": "isValidSignature",
+		"outputs": [
+			{
+				"internalType": "bytes4",
+				"name": "magicValue",
+				"type": "bytes4"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]
+`
@@ -22,3 +22,15 @@ const ERC165ABI = `
     "type": "function"
   }
 ]`
+
+// ERC-165 interface identifiers.
+var (
+	ERC165InterfaceID           = [4]byte{0x01, 0xff, 0xc9, 0xa7}
+	ERC165InvalidInterfaceID    = [4]byte{0xff, 0xff, 0xff, 0xff}
+	ERC721InterfaceID           = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	ERC721MetadataInterfaceID   = [4]byte{0x5b, 0x5e, 0x13, 0x9f}
+	ERC721EnumerableInterfaceID = [4]byte{0x78, 0x0e, 0x9d, 0x63}
+	ERC1155InterfaceID          = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+	ERC2981InterfaceID          = [4]byte{0x2a, 0x55, 0x20, 0x5a}
+	ERC4907InterfaceID          = [4]byte{0xad, 0x09, 0x2b, 0x5c}
+)
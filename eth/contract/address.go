@@ -0,0 +1,24 @@
+package contract
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrInvalidAddress = errors.New("invalid address")
+)
+
+// RequireAddresses returns ErrInvalidAddress for the first of addresses that
+// is not a hex address. common.HexToAddress would read it as the zero address.
+func RequireAddresses(addresses ...string) error {
+	for _, address := range addresses {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("%w: %q", ErrInvalidAddress, address)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	ErrConflictingBlock = errors.New("call options select more than one block")
+	ErrInvalidBlockTag  = errors.New("invalid block tag")
+	ErrPendingBlock     = errors.New("the pending block cannot be pinned")
+	ErrInvalidBlockHash = errors.New("invalid block hash")
+)
+
+type BlockTag string
+
+const (
+	BlockLatest    BlockTag = "latest"
+	BlockPending   BlockTag = "pending"
+	BlockSafe      BlockTag = "safe"
+	BlockFinalized BlockTag = "finalized"
+)
+
+// CallOpts selects the caller and the state a call reads. At most one of
+// BlockNumber, BlockHash and Block may be set; the zero value reads latest.
+type CallOpts struct {
+	From        string
+	BlockNumber *big.Int
+	BlockHash   string
+	Block       BlockTag
+}
+
+// PinBlock resolves the block selected by opts to its hash and returns options
+// reading that block, so that several calls observe one consistent state even
+// when new blocks arrive in between.
+func PinBlock(ctx context.Context, provider provider.Provider, opts *CallOpts) (*CallOpts, *types.Header, error) {
+	if opts == nil {
+		opts = &CallOpts{}
+	}
+	if err := opts.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	number, err := opts.blockNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var header *types.Header
+	switch {
+	case opts.BlockHash != "":
+		header, err = provider.HeaderByHash(ctx, opts.BlockHash)
+	case opts.Block == BlockPending:
+		return nil, nil, ErrPendingBlock
+	default:
+		header, err = provider.HeaderByNumber(ctx, number)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &CallOpts{From: opts.From, BlockHash: header.Origin.Hash().Hex()}, header, nil
+}
+
+func (o *CallOpts) toBind(ctx context.Context) (*bind.CallOpts, error) {
+	callOpts := &bind.CallOpts{Context: ctx}
+	if o == nil {
+		return callOpts, nil
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	number, err := o.blockNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	if o.From != "" {
+		callOpts.From = common.HexToAddress(o.From)
+	}
+	if o.BlockHash != "" {
+		callOpts.BlockHash = common.HexToHash(o.BlockHash)
+	}
+	callOpts.Pending = o.Block == BlockPending
+	if !callOpts.Pending {
+		callOpts.BlockNumber = number
+	}
+
+	return callOpts, nil
+}
+
+// validate rejects a From or BlockHash that HexToAddress or HexToHash would
+// silently turn into another value.
+func (o *CallOpts) validate() error {
+	if o.From != "" {
+		if err := RequireAddresses(o.From); err != nil {
+			return err
+		}
+	}
+	if o.BlockHash != "" {
+		hash, err := hexutil.Decode(o.BlockHash)
+		if err != nil || len(hash) != common.HashLength {
+			return fmt.Errorf("%w: %q", ErrInvalidBlockHash, o.BlockHash)
+		}
+	}
+
+	return nil
+}
+
+// blockNumber validates the block selection and returns it as the number
+// understood by ethclient, where negative values stand for the named tags.
+func (o *CallOpts) blockNumber() (*big.Int, error) {
+	selected := 0
+	if o.BlockNumber != nil {
+		selected++
+	}
+	if o.BlockHash != "" {
+		selected++
+	}
+	if o.Block != "" {
+		selected++
+	}
+	if selected > 1 {
+		return nil, ErrConflictingBlock
+	}
+
+	switch o.Block {
+	case "", BlockLatest:
+		return o.BlockNumber, nil
+	case BlockPending:
+		return big.NewInt(int64(rpc.PendingBlockNumber)), nil
+	case BlockSafe:
+		return big.NewInt(int64(rpc.SafeBlockNumber)), nil
+	case BlockFinalized:
+		return big.NewInt(int64(rpc.FinalizedBlockNumber)), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidBlockTag, o.Block)
+	}
+}
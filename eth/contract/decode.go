@@ -0,0 +1,361 @@
+package contract
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	ErrInvalidDecodeTarget   = errors.New("decode target must be a non-nil pointer")
+	ErrUnsupportedConversion = errors.New("unsupported conversion")
+	ErrMissingOutput         = errors.New("no output matches field")
+)
+
+var (
+	bigIntType  = reflect.TypeOf((*big.Int)(nil))
+	addressType = reflect.TypeOf(common.Address{})
+	hashType    = reflect.TypeOf(common.Hash{})
+	stringType  = reflect.TypeOf("")
+)
+
+// Decode stores the results in the value pointed to by v.
+//
+// A single output is decoded directly into v, so it may be a scalar, slice or,
+// for a tuple output, a struct. Multiple outputs require a struct; each
+// exported field is matched to the output named by its `abi:"name"` tag, or
+// by its own name ignoring case and underscores. Unnamed outputs are assigned
+// to fields in order. Fields tagged `abi:"-"` are skipped.
+func (r ContractResults) Decode(v any) error {
+	dst, err := decodeTarget(v)
+	if err != nil {
+		return err
+	}
+
+	if len(r) == 1 {
+		value := reflect.ValueOf(r[0].Value)
+		if dst.Kind() != reflect.Struct || value.Kind() == reflect.Struct {
+			return decodeValue(value, dst)
+		}
+	}
+
+	if dst.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %d outputs into %s", ErrUnsupportedConversion, len(r), dst.Type())
+	}
+
+	named := false
+	for _, result := range r {
+		if result.Name != "" {
+			named = true
+			break
+		}
+	}
+
+	position := 0
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		key, explicit, ok := fieldKey(field)
+		if !ok {
+			continue
+		}
+
+		var result *ContractResult
+		if named {
+			for j := range r {
+				if normalizeName(r[j].Name) == normalizeName(key) {
+					result = &r[j]
+					break
+				}
+			}
+		} else if position < len(r) {
+			result = &r[position]
+			position++
+		}
+
+		if result == nil {
+			if explicit {
+				return fmt.Errorf("%w: %s", ErrMissingOutput, key)
+			}
+			continue
+		}
+
+		if err := decodeValue(reflect.ValueOf(result.Value), dst.Field(i)); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Decode stores the value in the value pointed to by v, converting between
+// compatible ABI and Go types.
+func (v ContractResult) Decode(out any) error {
+	dst, err := decodeTarget(out)
+	if err != nil {
+		return err
+	}
+
+	return decodeValue(reflect.ValueOf(v.Value), dst)
+}
+
+func decodeTarget(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return reflect.Value{}, ErrInvalidDecodeTarget
+	}
+	return rv.Elem(), nil
+}
+
+func decodeValue(src reflect.Value, dst reflect.Value) error {
+	if !src.IsValid() {
+		return fmt.Errorf("%w: nil into %s", ErrUnsupportedConversion, dst.Type())
+	}
+
+	dstType := dst.Type()
+
+	if dst.Kind() == reflect.Interface && src.Type().Implements(dstType) {
+		dst.Set(src)
+		return nil
+	}
+
+	if dstType == bigIntType {
+		n, ok := toBigInt(src)
+		if !ok {
+			return mismatch(src, dstType)
+		}
+		dst.Set(reflect.ValueOf(n))
+		return nil
+	}
+
+	if src.Type().AssignableTo(dstType) {
+		dst.Set(src)
+		return nil
+	}
+
+	switch {
+	case dstType == stringType:
+		return decodeString(src, dst)
+	case dstType == addressType:
+		return decodeAddress(src, dst)
+	case dstType == hashType || isByteArray(dstType):
+		return decodeFixedBytes(src, dst)
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(dstType.Elem())
+		if err := decodeValue(src, elem.Elem()); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return decodeInteger(src, dst)
+
+	case reflect.Bool:
+		if src.Kind() != reflect.Bool {
+			return mismatch(src, dstType)
+		}
+		dst.SetBool(src.Bool())
+		return nil
+
+	case reflect.Slice:
+		if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+			return mismatch(src, dstType)
+		}
+		out := reflect.MakeSlice(dstType, src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := decodeValue(src.Index(i), out.Index(i)); err != nil {
+				return fmt.Errorf("index %d: %w", i, err)
+			}
+		}
+		dst.Set(out)
+		return nil
+
+	case reflect.Array:
+		if (src.Kind() != reflect.Slice && src.Kind() != reflect.Array) || src.Len() != dst.Len() {
+			return mismatch(src, dstType)
+		}
+		for i := 0; i < src.Len(); i++ {
+			if err := decodeValue(src.Index(i), dst.Index(i)); err != nil {
+				return fmt.Errorf("index %d: %w", i, err)
+			}
+		}
+		return nil
+
+	case reflect.Struct:
+		if src.Kind() != reflect.Struct {
+			return mismatch(src, dstType)
+		}
+		return decodeStruct(src, dst)
+	}
+
+	return mismatch(src, dstType)
+}
+
+// decodeStruct copies a tuple, which go-ethereum unpacks into an anonymous
+// struct whose fields carry the ABI component names in their json tags.
+func decodeStruct(src reflect.Value, dst reflect.Value) error {
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		key, explicit, ok := fieldKey(field)
+		if !ok {
+			continue
+		}
+
+		var value reflect.Value
+		for j := 0; j < src.NumField(); j++ {
+			srcField := src.Type().Field(j)
+			name := srcField.Tag.Get("json")
+			if name == "" {
+				name = srcField.Name
+			}
+			if normalizeName(name) == normalizeName(key) {
+				value = src.Field(j)
+				break
+			}
+		}
+
+		if !value.IsValid() {
+			if explicit {
+				return fmt.Errorf("%w: %s", ErrMissingOutput, key)
+			}
+			continue
+		}
+
+		if err := decodeValue(value, dst.Field(i)); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func decodeString(src reflect.Value, dst reflect.Value) error {
+	switch {
+	case src.Kind() == reflect.String:
+		dst.SetString(src.String())
+	case src.Type() == addressType:
+		dst.SetString(src.Interface().(common.Address).Hex())
+	case src.Type() == hashType || isByteArray(src.Type()) && src.Len() == 32:
+		dst.SetString(hexutil.Encode(byteArray(src)))
+	default:
+		return mismatch(src, dst.Type())
+	}
+	return nil
+}
+
+func decodeAddress(src reflect.Value, dst reflect.Value) error {
+	switch {
+	case src.Kind() == reflect.String:
+		if !common.IsHexAddress(src.String()) {
+			return mismatch(src, dst.Type())
+		}
+		dst.Set(reflect.ValueOf(common.HexToAddress(src.String())))
+	case isByteArray(src.Type()) && src.Len() == common.AddressLength:
+		dst.Set(reflect.ValueOf(common.BytesToAddress(byteArray(src))))
+	default:
+		return mismatch(src, dst.Type())
+	}
+	return nil
+}
+
+func decodeFixedBytes(src reflect.Value, dst reflect.Value) error {
+	var data []byte
+
+	switch {
+	case src.Kind() == reflect.String:
+		b, err := hexutil.Decode(src.String())
+		if err != nil {
+			return mismatch(src, dst.Type())
+		}
+		data = b
+	case isByteArray(src.Type()):
+		data = byteArray(src)
+	case src.Type() == addressType && dst.Len() == common.AddressLength:
+		data = src.Interface().(common.Address).Bytes()
+	default:
+		return mismatch(src, dst.Type())
+	}
+
+	if len(data) != dst.Len() {
+		return mismatch(src, dst.Type())
+	}
+	reflect.Copy(dst, reflect.ValueOf(data))
+	return nil
+}
+
+func decodeInteger(src reflect.Value, dst reflect.Value) error {
+	n, ok := toBigInt(src)
+	if !ok {
+		return mismatch(src, dst.Type())
+	}
+
+	switch dst.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n.Sign() < 0 || !n.IsUint64() || dst.OverflowUint(n.Uint64()) {
+			return fmt.Errorf("%w: %s overflows %s", ErrUnsupportedConversion, n, dst.Type())
+		}
+		dst.SetUint(n.Uint64())
+	default:
+		if !n.IsInt64() || dst.OverflowInt(n.Int64()) {
+			return fmt.Errorf("%w: %s overflows %s", ErrUnsupportedConversion, n, dst.Type())
+		}
+		dst.SetInt(n.Int64())
+	}
+	return nil
+}
+
+func toBigInt(src reflect.Value) (*big.Int, bool) {
+	switch src.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(src.Uint()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(src.Int()), true
+	}
+	if src.Type() == bigIntType && !src.IsNil() {
+		return new(big.Int).Set(src.Interface().(*big.Int)), true
+	}
+	return nil, false
+}
+
+func fieldKey(field reflect.StructField) (key string, explicit bool, ok bool) {
+	if !field.IsExported() {
+		return "", false, false
+	}
+
+	tag := field.Tag.Get("abi")
+	switch tag {
+	case "-":
+		return "", false, false
+	case "":
+		return field.Name, false, true
+	default:
+		return tag, true, true
+	}
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+func isByteArray(t reflect.Type) bool {
+	return t.Kind() == reflect.Array && t.Elem().Kind() == reflect.Uint8
+}
+
+func byteArray(v reflect.Value) []byte {
+	out := make([]byte, v.Len())
+	reflect.Copy(reflect.ValueOf(out), v)
+	return out
+}
+
+func mismatch(src reflect.Value, dst reflect.Type) error {
+	return fmt.Errorf("%w: %s into %s", ErrUnsupportedConversion, src.Type(), dst)
+}
@@ -0,0 +1,247 @@
+package contract
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	testAddress = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	testHash    = common.HexToHash("0x0102030405060708091011121314151617181920212223242526272829303132")
+)
+
+func TestDecodeNamedOutputs(t *testing.T) {
+	results := ContractResults{
+		{Name: "owner", Value: testAddress},
+		{Name: "token_id", Value: big.NewInt(7)},
+		{Name: "_amount", Value: big.NewInt(3)},
+		{Name: "active", Value: true},
+	}
+
+	var out struct {
+		Holder  common.Address `abi:"owner"`
+		TokenID *big.Int
+		Amount  uint64
+		Active  bool
+		Skipped string `abi:"-"`
+		hidden  string
+	}
+	out.Skipped = "kept"
+
+	if err := results.Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Holder != testAddress {
+		t.Errorf("Holder = %s, want %s", out.Holder, testAddress)
+	}
+	if out.TokenID.Cmp(big.NewInt(7)) != 0 {
+		t.Errorf("TokenID = %s, want 7", out.TokenID)
+	}
+	if out.Amount != 3 {
+		t.Errorf("Amount = %d, want 3", out.Amount)
+	}
+	if !out.Active {
+		t.Error("Active = false, want true")
+	}
+	if out.Skipped != "kept" || out.hidden != "" {
+		t.Errorf("skipped fields were written: %q, %q", out.Skipped, out.hidden)
+	}
+}
+
+func TestDecodeMissingTaggedOutput(t *testing.T) {
+	results := ContractResults{
+		{Name: "a", Value: big.NewInt(1)},
+		{Name: "b", Value: big.NewInt(2)},
+	}
+
+	var out struct {
+		A *big.Int
+		C *big.Int `abi:"c"`
+	}
+	if err := results.Decode(&out); !errors.Is(err, ErrMissingOutput) {
+		t.Fatalf("got %v, want ErrMissingOutput", err)
+	}
+
+	var untagged struct {
+		A *big.Int
+		C *big.Int
+	}
+	if err := results.Decode(&untagged); err != nil {
+		t.Fatalf("untagged field without output: %v", err)
+	}
+	if untagged.C != nil {
+		t.Errorf("C = %s, want nil", untagged.C)
+	}
+}
+
+func TestDecodePositionalOutputs(t *testing.T) {
+	results := ContractResults{
+		{Value: "name"},
+		{Value: uint8(18)},
+		{Value: big.NewInt(1000)},
+	}
+
+	var out struct {
+		Name     string
+		Decimals uint8
+		Supply   *big.Int
+	}
+	if err := results.Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "name" || out.Decimals != 18 || out.Supply.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("got %+v", out)
+	}
+}
+
+func TestDecodeSingleOutput(t *testing.T) {
+	var n *big.Int
+	if err := (ContractResults{{Value: uint32(5)}}).Decode(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n.Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("got %s, want 5", n)
+	}
+
+	tuple := struct {
+		Owner  common.Address `json:"owner"`
+		Amount *big.Int       `json:"amount"`
+	}{testAddress, big.NewInt(9)}
+
+	var out struct {
+		Owner  string
+		Amount int64
+	}
+	if err := (ContractResults{{Name: "position", Value: tuple}}).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Owner != testAddress.Hex() || out.Amount != 9 {
+		t.Errorf("got %+v", out)
+	}
+}
+
+func TestDecodeConversions(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		check func(t *testing.T, result ContractResult)
+	}{
+		{
+			name:  "address to string",
+			value: testAddress,
+			check: func(t *testing.T, result ContractResult) {
+				var out string
+				if err := result.Decode(&out); err != nil || out != testAddress.Hex() {
+					t.Errorf("got %q, %v", out, err)
+				}
+			},
+		},
+		{
+			name:  "string to address",
+			value: testAddress.Hex(),
+			check: func(t *testing.T, result ContractResult) {
+				var out common.Address
+				if err := result.Decode(&out); err != nil || out != testAddress {
+					t.Errorf("got %s, %v", out, err)
+				}
+			},
+		},
+		{
+			name:  "bytes32 to string",
+			value: [32]byte(testHash),
+			check: func(t *testing.T, result ContractResult) {
+				var out string
+				if err := result.Decode(&out); err != nil || out != testHash.Hex() {
+					t.Errorf("got %q, %v", out, err)
+				}
+			},
+		},
+		{
+			name:  "bytes32 to hash",
+			value: [32]byte(testHash),
+			check: func(t *testing.T, result ContractResult) {
+				var out common.Hash
+				if err := result.Decode(&out); err != nil || out != testHash {
+					t.Errorf("got %s, %v", out, err)
+				}
+			},
+		},
+		{
+			name:  "hex string to bytes32",
+			value: testHash.Hex(),
+			check: func(t *testing.T, result ContractResult) {
+				var out [32]byte
+				if err := result.Decode(&out); err != nil || out != [32]byte(testHash) {
+					t.Errorf("got %x, %v", out, err)
+				}
+			},
+		},
+		{
+			name:  "address slice to strings",
+			value: []common.Address{testAddress},
+			check: func(t *testing.T, result ContractResult) {
+				var out []string
+				if err := result.Decode(&out); err != nil || len(out) != 1 || out[0] != testAddress.Hex() {
+					t.Errorf("got %v, %v", out, err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, ContractResult{Value: tt.value})
+		})
+	}
+}
+
+func TestDecodeMismatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		out   any
+	}{
+		{"bool into big.Int", true, new(*big.Int)},
+		{"string into bool", "true", new(bool)},
+		{"invalid address string", "0x1234", new(common.Address)},
+		{"bytes32 into address", [32]byte{}, new(common.Address)},
+		{"short hex into bytes32", "0x01", new([32]byte)},
+		{"overflowing uint8", big.NewInt(256), new(uint8)},
+		{"negative into uint", big.NewInt(-1), new(uint64)},
+		{"nil value", nil, new(string)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ContractResult{Value: tt.value}.Decode(tt.out)
+			if !errors.Is(err, ErrUnsupportedConversion) {
+				t.Errorf("got %v, want ErrUnsupportedConversion", err)
+			}
+		})
+	}
+
+	results := ContractResults{{Value: big.NewInt(1)}, {Value: big.NewInt(2)}}
+	var scalar *big.Int
+	if err := results.Decode(&scalar); !errors.Is(err, ErrUnsupportedConversion) {
+		t.Errorf("multiple outputs into scalar: got %v, want ErrUnsupportedConversion", err)
+	}
+
+	var field struct{ A bool }
+	if err := (ContractResults{{Name: "a", Value: "x"}, {Name: "b", Value: "y"}}).Decode(&field); !errors.Is(err, ErrUnsupportedConversion) {
+		t.Errorf("field mismatch: got %v, want ErrUnsupportedConversion", err)
+	}
+}
+
+func TestDecodeInvalidTarget(t *testing.T) {
+	results := ContractResults{{Value: big.NewInt(1)}}
+
+	var n *big.Int
+	for _, target := range []any{nil, n, *new(big.Int)} {
+		if err := results.Decode(target); !errors.Is(err, ErrInvalidDecodeTarget) {
+			t.Errorf("Decode(%T) = %v, want ErrInvalidDecodeTarget", target, err)
+		}
+	}
+}
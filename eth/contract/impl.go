@@ -4,19 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/revert"
+	"github.com/dtome123/go-bcwe3/eth/signer"
 	"github.com/dtome123/go-bcwe3/eth/types"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
 	"github.com/ethereum/go-ethereum/common"
+	goethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
 type implContract struct {
 	address       string
 	provider      provider.Provider
+	abi           abi.ABI
 	boundContract *bind.BoundContract
 }
 
@@ -39,32 +44,63 @@ func NewContract(provider provider.Provider, address, abiData string) (Contract,
 	return &implContract{
 		provider:      provider,
 		address:       address,
+		abi:           parsedABI,
 		boundContract: contract,
 	}, nil
 }
 
+// Call calls a constant method at the latest block.
 func (c *implContract) Call(ctx context.Context, method string, params ...any) (ContractResults, error) {
+	return c.CallWithOpts(ctx, nil, method, params...)
+}
+
+// CallWithOpts is Call reading the block and caller selected by opts; nil opts read latest.
+func (c *implContract) CallWithOpts(ctx context.Context, opts *CallOpts, method string, params ...any) (ContractResults, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 	if method == "" {
 		return nil, errors.New("method name cannot be empty")
 	}
+	callOpts, err := opts.toBind(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	var result []any
-	err := c.boundContract.Call(&bind.CallOpts{Context: ctx}, &result, method, params...)
+	err = c.boundContract.Call(callOpts, &result, method, params...)
 	if err != nil {
-		return nil, err
+		return nil, revert.Wrap(err, c.abi)
 	}
 
+	outputs := c.abi.Methods[method].Outputs
 	contractResults := make(ContractResults, len(result))
 	for i, value := range result {
 		contractResults[i] = ContractResult{Value: value}
+		if i < len(outputs) {
+			contractResults[i].Name = outputs[i].Name
+		}
 	}
 
 	return contractResults, nil
 }
 
+// CallInto calls a constant method and decodes its outputs into out, see
+// ContractResults.Decode.
+func (c *implContract) CallInto(ctx context.Context, out any, method string, params ...any) error {
+	return c.CallIntoWithOpts(ctx, nil, out, method, params...)
+}
+
+// CallIntoWithOpts is CallInto reading the block and caller selected by opts.
+func (c *implContract) CallIntoWithOpts(ctx context.Context, opts *CallOpts, out any, method string, params ...any) error {
+	result, err := c.CallWithOpts(ctx, opts, method, params...)
+	if err != nil {
+		return err
+	}
+
+	return result.Decode(out)
+}
+
 // Transact sends a state-changing transaction to the contract.
 func (c *implContract) Transact(ctx context.Context, method string, privateKey string, params ...any) (*types.Tx, error) {
 	if ctx == nil {
@@ -91,8 +127,89 @@ func (c *implContract) Transact(ctx context.Context, method string, privateKey s
 
 	tx, err := c.boundContract.Transact(auth, method, params...)
 	if err != nil {
-		return nil, fmt.Errorf("transaction failed: %v", err)
+		return nil, fmt.Errorf("transaction failed: %w", revert.Wrap(err, c.abi))
 	}
 
 	return types.WrapTx(tx), nil
 }
+
+// TransactWithSigner sends a state-changing transaction signed by the given signer.
+// A nil opts lets the node estimate gas, fees and the nonce.
+func (c *implContract) TransactWithSigner(ctx context.Context, signer signer.Signer, opts *TransactOpts, method string, params ...any) (*types.Tx, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if method == "" {
+		return nil, errors.New("method cannot be empty")
+	}
+	if signer == nil {
+		return nil, errors.New("signer is required")
+	}
+
+	auth, err := NewTransactOpts(ctx, c.provider, signer, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := c.boundContract.Transact(auth, method, params...)
+	if err != nil {
+		return nil, fmt.Errorf("transaction failed: %w", revert.Wrap(err, c.abi))
+	}
+
+	return types.WrapTx(tx), nil
+}
+
+// ReplayTransaction returns the decoded *revert.RevertError of a failed
+// transaction, see provider.Provider.ReplayTransaction. It returns nil for
+// successful transactions or when the failure cannot be reproduced.
+func (c *implContract) ReplayTransaction(ctx context.Context, txHash string) error {
+	reason, err := c.provider.ReplayTransaction(ctx, txHash, c.abi)
+	if errors.Is(err, provider.ErrTransactionNotReverted) || errors.Is(err, provider.ErrRevertNotReproduced) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return reason
+}
+
+func (c *implContract) ABI() abi.ABI {
+	return c.abi
+}
+
+// NewTransactOpts builds go-ethereum transact options that sign with s on the
+// provider's chain, applying any overrides from opts.
+func NewTransactOpts(ctx context.Context, provider provider.Provider, s signer.Signer, opts *TransactOpts) (*bind.TransactOpts, error) {
+	chainID, err := provider.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %v", err)
+	}
+
+	from := common.HexToAddress(s.Address())
+	auth := &bind.TransactOpts{
+		From:    from,
+		Context: ctx,
+		Signer: func(address common.Address, tx *goethTypes.Transaction) (*goethTypes.Transaction, error) {
+			if address != from {
+				return nil, bind.ErrNotAuthorized
+			}
+			return s.SignTx(tx, chainID)
+		},
+	}
+
+	if opts == nil {
+		return auth, nil
+	}
+
+	if opts.Nonce != nil {
+		auth.Nonce = new(big.Int).SetUint64(*opts.Nonce)
+	}
+	auth.Value = opts.Value
+	auth.GasLimit = opts.GasLimit
+	auth.GasPrice = opts.GasPrice
+	auth.GasFeeCap = opts.GasFeeCap
+	auth.GasTipCap = opts.GasTipCap
+
+	return auth, nil
+}
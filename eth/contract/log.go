@@ -0,0 +1,97 @@
+package contract
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dtome123/go-bcwe3/eth/revert"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	goethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	ErrEventNotFound = errors.New("event not found in ABI")
+	ErrEventMismatch = errors.New("log does not match event")
+)
+
+// UnpackLog decodes log as the event name of contractABI. Indexed and
+// non-indexed arguments are returned in declaration order, unnamed ones as
+// arg<position>; indexed dynamic values (string, bytes, arrays, tuples) are
+// only available as their common.Hash topic.
+func UnpackLog(contractABI abi.ABI, name string, log goethTypes.Log) (ContractResults, error) {
+	event, ok := contractABI.Events[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrEventNotFound, name)
+	}
+
+	return UnpackEvent(event, log)
+}
+
+// UnpackEvent decodes log as event. It is UnpackLog for callers that hold the
+// abi.Event rather than the contract ABI.
+func UnpackEvent(event abi.Event, log goethTypes.Log) (ContractResults, error) {
+	if len(log.Topics) == 0 || (!event.Anonymous && log.Topics[0] != event.ID) {
+		return nil, fmt.Errorf("%w: %s", ErrEventMismatch, event.Name)
+	}
+
+	// Unnamed inputs are keyed by position so they do not collide in the map.
+	inputs := make(abi.Arguments, len(event.Inputs))
+	var indexed abi.Arguments
+	for i, input := range event.Inputs {
+		if input.Name == "" {
+			input.Name = fmt.Sprintf("arg%d", i)
+		}
+		inputs[i] = input
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+
+	topics := log.Topics
+	if !event.Anonymous {
+		topics = topics[1:]
+	}
+	if len(topics) != len(indexed) {
+		return nil, fmt.Errorf("%w: %s", ErrEventMismatch, event.Name)
+	}
+
+	values := make(map[string]any, len(inputs))
+
+	if len(indexed) < len(inputs) {
+		if len(log.Data) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrEventMismatch, event.Name)
+		}
+		if err := inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := abi.ParseTopicsIntoMap(values, indexed, topics); err != nil {
+		return nil, err
+	}
+
+	results := make(ContractResults, len(inputs))
+	for i, input := range inputs {
+		results[i] = ContractResult{Name: event.Inputs[i].Name, Value: values[input.Name]}
+	}
+
+	return results, nil
+}
+
+// ErrorAs reports whether err is a revert with the custom error name of
+// contractABI and, if so, decodes its arguments into out like
+// ContractResults.Decode.
+func ErrorAs(err error, contractABI abi.ABI, name string, out any) bool {
+	reason, ok := revert.FromError(err, contractABI)
+	if !ok || reason.Kind != revert.KindCustom || reason.Name != name {
+		return false
+	}
+
+	abiErr := contractABI.Errors[name]
+	results := make(ContractResults, len(reason.Values))
+	for i, value := range reason.Values {
+		results[i] = ContractResult{Name: abiErr.Inputs[i].Name, Value: value}
+	}
+
+	return results.Decode(out) == nil
+}
@@ -3,9 +3,24 @@ package contract
 import (
 	"fmt"
 	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
+// TransactOpts overrides the values otherwise filled in by the node when sending
+// a transaction. Zero values mean "estimate" or "use the pending state".
+type TransactOpts struct {
+	Value     *big.Int
+	Nonce     *uint64
+	GasLimit  uint64
+	GasPrice  *big.Int
+	GasFeeCap *big.Int
+	GasTipCap *big.Int
+}
+
 type ContractResult struct {
+	// Name is the ABI output name, empty for unnamed outputs.
+	Name  string
 	Value interface{}
 }
 
@@ -119,6 +134,14 @@ func (v ContractResult) AsBytes() ([]byte, error) {
 	return num, nil
 }
 
+func (v ContractResult) AsAddress() (common.Address, error) {
+	addr, ok := v.Value.(common.Address)
+	if !ok {
+		return common.Address{}, fmt.Errorf("value is not common.Address")
+	}
+	return addr, nil
+}
+
 func (v ContractResult) AsBigInt() (*big.Int, error) {
 	num, ok := v.Value.(*big.Int)
 	if !ok {
@@ -127,6 +150,14 @@ func (v ContractResult) AsBigInt() (*big.Int, error) {
 	return num, nil
 }
 
+func (v ContractResult) AsBigInts() ([]*big.Int, error) {
+	nums, ok := v.Value.([]*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("value is not []*big.Int")
+	}
+	return nums, nil
+}
+
 func (v ContractResult) AsBigFloat() (*big.Float, error) {
 	num, ok := v.Value.(*big.Float)
 	if !ok {
@@ -3,10 +3,19 @@ package contract
 import (
 	"context"
 
+	"github.com/dtome123/go-bcwe3/eth/signer"
 	"github.com/dtome123/go-bcwe3/eth/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 )
 
 type Contract interface {
 	Transact(ctx context.Context, method string, privateKey string, params ...any) (*types.Tx, error)
+	TransactWithSigner(ctx context.Context, signer signer.Signer, opts *TransactOpts, method string, params ...any) (*types.Tx, error)
 	Call(ctx context.Context, method string, params ...interface{}) (ContractResults, error)
+	CallWithOpts(ctx context.Context, opts *CallOpts, method string, params ...any) (ContractResults, error)
+	CallInto(ctx context.Context, out any, method string, params ...any) error
+	CallIntoWithOpts(ctx context.Context, opts *CallOpts, out any, method string, params ...any) error
+	// ReplayTransaction replays a failed transaction and returns its decoded revert.
+	ReplayTransaction(ctx context.Context, txHash string) error
+	ABI() abi.ABI
 }
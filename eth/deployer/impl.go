@@ -0,0 +1,202 @@
+package deployer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dtome123/go-bcwe3/eth/constants"
+	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/revert"
+	"github.com/dtome123/go-bcwe3/eth/signer"
+	"github.com/dtome123/go-bcwe3/eth/types"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	ErrEmptyBytecode      = errors.New("bytecode is empty")
+	ErrDeploymentReverted = errors.New("deployment transaction reverted")
+	ErrNoRuntimeCode      = errors.New("no runtime code at deployed address")
+	ErrFactoryNotDeployed = errors.New("CREATE2 factory is not deployed on this chain")
+	ErrAlreadyDeployed    = errors.New("contract already deployed at predicted address")
+	ErrUnexpectedAddress  = errors.New("factory deployed to an unexpected address")
+)
+
+type impl struct {
+	provider provider.Provider
+	factory  common.Address
+}
+
+func NewDeployer(provider provider.Provider, config *Config) Deployer {
+	factory := constants.Create2FactoryAddress
+	if config != nil && config.Factory != "" {
+		factory = config.Factory
+	}
+
+	return &impl{
+		provider: provider,
+		factory:  common.HexToAddress(factory),
+	}
+}
+
+func (d *impl) Factory() string {
+	return d.factory.Hex()
+}
+
+func (d *impl) Deploy(ctx context.Context, signer signer.Signer, opts *contract.TransactOpts, abiData string, bytecode string, args ...any) (*Deployment, error) {
+	parsedABI, initCode, err := buildInitCode(abiData, bytecode, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := contract.NewTransactOpts(ctx, d.provider, signer, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	client := d.provider.Client()
+	address, tx, err := bind.DeployContract(auth, initCode, client, nil)
+	if err != nil {
+		return nil, fmt.Errorf("deployment failed: %w", revert.Wrap(err, parsedABI))
+	}
+
+	return d.confirm(ctx, address, types.WrapTx(tx), parsedABI, abiData)
+}
+
+func (d *impl) DeployCreate2(ctx context.Context, signer signer.Signer, opts *contract.TransactOpts, salt [32]byte, abiData string, bytecode string, args ...any) (*Deployment, error) {
+	parsedABI, initCode, err := buildInitCode(abiData, bytecode, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	factoryCode, err := d.provider.CodeAt(ctx, d.factory.Hex(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(factoryCode) == 0 {
+		return nil, ErrFactoryNotDeployed
+	}
+
+	address := crypto.CreateAddress2(d.factory, salt, crypto.Keccak256(initCode))
+
+	existing, err := d.provider.CodeAt(ctx, address.Hex(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyDeployed, address.Hex())
+	}
+
+	auth, err := contract.NewTransactOpts(ctx, d.provider, signer, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	calldata := append(salt[:], initCode...)
+
+	// Gas estimation simulates the factory call, so constructor reverts
+	// surface here before a transaction is sent.
+	client := d.provider.Client()
+	factory := bind.NewBoundContract(d.factory, abi.ABI{}, client, client, client)
+
+	tx, err := factory.RawTransact(auth, calldata)
+	if err != nil {
+		return nil, fmt.Errorf("deployment failed: %w", revert.Wrap(err, parsedABI))
+	}
+
+	return d.confirm(ctx, address, types.WrapTx(tx), parsedABI, abiData)
+}
+
+func (d *impl) PredictCreate2(salt [32]byte, abiData string, bytecode string, args ...any) (string, error) {
+	_, initCode, err := buildInitCode(abiData, bytecode, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return PredictCreate2Address(d.factory.Hex(), salt, initCode), nil
+}
+
+// confirm waits for the deployment, checks that code was created and binds it.
+func (d *impl) confirm(ctx context.Context, address common.Address, tx *types.Tx, parsedABI abi.ABI, abiData string) (*Deployment, error) {
+	receipt, err := d.provider.WaitMined(ctx, tx.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := &Deployment{
+		Address: address.Hex(),
+		Tx:      tx,
+		Receipt: receipt,
+	}
+
+	if receipt.Status != 1 {
+		if reason, err := d.provider.ReplayTransaction(ctx, tx.Hash, parsedABI); err == nil {
+			return deployment, fmt.Errorf("%w: %s: %w", ErrDeploymentReverted, tx.Hash, reason)
+		}
+		return deployment, fmt.Errorf("%w: %s", ErrDeploymentReverted, tx.Hash)
+	}
+
+	if receipt.ContractAddress != "" && receipt.ContractAddress != (common.Address{}).Hex() &&
+		common.HexToAddress(receipt.ContractAddress) != address {
+		return deployment, fmt.Errorf("%w: %s", ErrUnexpectedAddress, receipt.ContractAddress)
+	}
+
+	code, err := d.provider.CodeAt(ctx, address.Hex(), receipt.BlockNumber)
+	if err != nil {
+		return deployment, err
+	}
+	if len(code) == 0 {
+		return deployment, fmt.Errorf("%w: %s", ErrNoRuntimeCode, address.Hex())
+	}
+
+	bound, err := contract.NewContract(d.provider, address.Hex(), abiData)
+	if err != nil {
+		return deployment, err
+	}
+	deployment.Contract = bound
+
+	return deployment, nil
+}
+
+// PredictCreateAddress returns the address of the contract created by deployer
+// with the given account nonce.
+func PredictCreateAddress(deployer string, nonce uint64) string {
+	return crypto.CreateAddress(common.HexToAddress(deployer), nonce).Hex()
+}
+
+// PredictCreate2Address returns the address of the contract created by factory
+// with CREATE2 for salt and initCode.
+func PredictCreate2Address(factory string, salt [32]byte, initCode []byte) string {
+	return crypto.CreateAddress2(common.HexToAddress(factory), salt, crypto.Keccak256(initCode)).Hex()
+}
+
+// InitCode returns bytecode followed by the ABI encoded constructor args.
+func InitCode(abiData string, bytecode string, args ...any) ([]byte, error) {
+	_, initCode, err := buildInitCode(abiData, bytecode, args...)
+	return initCode, err
+}
+
+func buildInitCode(abiData string, bytecode string, args ...any) (abi.ABI, []byte, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(abiData))
+	if err != nil {
+		return abi.ABI{}, nil, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	code := common.FromHex(strings.TrimSpace(bytecode))
+	if len(code) == 0 {
+		return abi.ABI{}, nil, ErrEmptyBytecode
+	}
+
+	input, err := parsedABI.Pack("", args...)
+	if err != nil {
+		return abi.ABI{}, nil, fmt.Errorf("failed to pack constructor args: %v", err)
+	}
+
+	return parsedABI, append(code, input...), nil
+}
@@ -0,0 +1,35 @@
+package deployer
+
+import (
+	"context"
+
+	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/signer"
+	"github.com/dtome123/go-bcwe3/eth/types"
+)
+
+type Deployer interface {
+	// Deploy sends a CREATE transaction with bytecode and the ABI encoded
+	// constructor args, waits for it to be mined and checks that runtime code
+	// exists at the new address.
+	Deploy(ctx context.Context, signer signer.Signer, opts *contract.TransactOpts, abiData string, bytecode string, args ...any) (*Deployment, error)
+	// DeployCreate2 deploys through the CREATE2 factory so that the address only
+	// depends on the factory, salt and init code.
+	DeployCreate2(ctx context.Context, signer signer.Signer, opts *contract.TransactOpts, salt [32]byte, abiData string, bytecode string, args ...any) (*Deployment, error)
+	// PredictCreate2 returns the address DeployCreate2 would deploy to.
+	PredictCreate2(salt [32]byte, abiData string, bytecode string, args ...any) (string, error)
+	// Factory returns the CREATE2 factory address in use.
+	Factory() string
+}
+
+type Config struct {
+	// Factory is the CREATE2 factory. Defaults to constants.Create2FactoryAddress.
+	Factory string
+}
+
+type Deployment struct {
+	Address  string
+	Tx       *types.Tx
+	Receipt  *types.Receipt
+	Contract contract.Contract
+}
@@ -0,0 +1,283 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/dtome123/go-bcwe3/eth/constants"
+	"github.com/dtome123/go-bcwe3/eth/erc165"
+	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/proxy"
+	"github.com/dtome123/go-bcwe3/eth/utils"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// minConfidence is the score below which a contract is classified as StandardOther.
+	minConfidence = 0.35
+
+	erc165Weight   = 0.5
+	selectorWeight = 0.4
+)
+
+// priority breaks ties in favour of the more specific standard (a vault is also an ERC-20).
+var priority = []Standard{StandardERC4626, StandardERC1155, StandardERC721, StandardERC20}
+
+var interfaceIDs = map[Standard][4]byte{
+	StandardERC721:  constants.ERC721InterfaceID,
+	StandardERC1155: constants.ERC1155InterfaceID,
+}
+
+var signatures = map[Standard][]string{
+	StandardERC20: {
+		"totalSupply()",
+		"balanceOf(address)",
+		"transfer(address,uint256)",
+		"transferFrom(address,address,uint256)",
+		"approve(address,uint256)",
+		"allowance(address,address)",
+	},
+	StandardERC721: {
+		"balanceOf(address)",
+		"ownerOf(uint256)",
+		"safeTransferFrom(address,address,uint256)",
+		"safeTransferFrom(address,address,uint256,bytes)",
+		"transferFrom(address,address,uint256)",
+		"approve(address,uint256)",
+		"setApprovalForAll(address,bool)",
+		"getApproved(uint256)",
+		"isApprovedForAll(address,address)",
+	},
+	StandardERC1155: {
+		"balanceOf(address,uint256)",
+		"balanceOfBatch(address[],uint256[])",
+		"safeTransferFrom(address,address,uint256,uint256,bytes)",
+		"safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)",
+		"setApprovalForAll(address,bool)",
+		"isApprovedForAll(address,address)",
+	},
+	StandardERC4626: {
+		"asset()",
+		"totalAssets()",
+		"convertToShares(uint256)",
+		"convertToAssets(uint256)",
+		"maxDeposit(address)",
+		"previewDeposit(uint256)",
+		"deposit(uint256,address)",
+		"mint(uint256,address)",
+		"withdraw(uint256,address,address)",
+		"redeem(uint256,address,address)",
+	},
+}
+
+type probe struct {
+	standard  Standard
+	signature string
+	args      []byte
+	// expectFailure marks probes that count as evidence when the call reverts.
+	expectFailure bool
+	weight        float64
+}
+
+var probes = []probe{
+	{standard: StandardERC20, signature: "decimals()", weight: 0.1},
+	{standard: StandardERC20, signature: "totalSupply()", weight: 0.1},
+	{standard: StandardERC20, signature: "balanceOf(address)", args: word(nil), weight: 0.1},
+	{standard: StandardERC721, signature: "balanceOf(address)", args: word(big.NewInt(1)), weight: 0.1},
+	{standard: StandardERC721, signature: "isApprovedForAll(address,address)", args: append(word(big.NewInt(1)), word(big.NewInt(2))...), weight: 0.1},
+	{standard: StandardERC721, signature: "decimals()", expectFailure: true, weight: 0.1},
+	{standard: StandardERC1155, signature: "balanceOf(address,uint256)", args: append(word(big.NewInt(1)), word(nil)...), weight: 0.2},
+	{standard: StandardERC1155, signature: "isApprovedForAll(address,address)", args: append(word(big.NewInt(1)), word(big.NewInt(2))...), weight: 0.1},
+	{standard: StandardERC4626, signature: "asset()", weight: 0.15},
+	{standard: StandardERC4626, signature: "totalAssets()", weight: 0.15},
+}
+
+type impl struct {
+	provider provider.Provider
+	proxies  proxy.Resolver
+}
+
+func NewDetector(provider provider.Provider) Detector {
+	return &impl{
+		provider: provider,
+		proxies:  proxy.NewResolver(provider),
+	}
+}
+
+func (d *impl) DetectStandard(ctx context.Context, address string) (*Detection, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid contract address: %s", address)
+	}
+
+	code, err := d.provider.CodeAt(ctx, address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	detection := &Detection{
+		Address:  common.HexToAddress(address).Hex(),
+		Standard: StandardOther,
+		Scores:   make(map[Standard]float64),
+	}
+
+	if len(code) == 0 {
+		detection.Evidence = append(detection.Evidence, Evidence{Source: SourceProbe, Detail: "no code at address"})
+		return detection, nil
+	}
+
+	resolution, err := d.proxies.Resolve(ctx, address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resolution.IsProxy() {
+		for _, hop := range resolution.Chain {
+			detection.Evidence = append(detection.Evidence, Evidence{
+				Source: SourceProxy,
+				Detail: fmt.Sprintf("%s proxy %s points to %s", hop.Kind, hop.Proxy, hop.Implementation),
+			})
+		}
+
+		code, err = d.provider.CodeAt(ctx, resolution.Implementation, nil)
+		if err != nil {
+			return nil, err
+		}
+		detection.Implementation = resolution.Implementation
+	}
+
+	d.probeERC165(ctx, address, detection)
+	scoreSelectors(code, detection)
+	d.runProbes(ctx, address, detection)
+
+	best := 0.0
+	for _, standard := range priority {
+		score := min(detection.Scores[standard], 1)
+		detection.Scores[standard] = score
+		if score > best {
+			best = score
+			detection.Standard = standard
+		}
+	}
+
+	if best < minConfidence {
+		detection.Standard = StandardOther
+		detection.Confidence = 1 - best
+	} else {
+		detection.Confidence = best
+	}
+
+	return detection, nil
+}
+
+func (d *impl) probeERC165(ctx context.Context, address string, detection *Detection) {
+	checker, err := erc165.New(address, d.provider)
+	if err != nil {
+		return
+	}
+
+	supported, err := checker.SupportInterface(ctx, address, constants.ERC165InterfaceID)
+	if err != nil || !supported {
+		detection.Evidence = append(detection.Evidence, Evidence{Source: SourceERC165, Detail: "ERC-165 not supported"})
+		return
+	}
+
+	// a compliant implementation must reject 0xffffffff
+	invalid, err := checker.SupportInterface(ctx, address, constants.ERC165InvalidInterfaceID)
+	if err != nil || invalid {
+		detection.Evidence = append(detection.Evidence, Evidence{Source: SourceERC165, Detail: "supportsInterface(0xffffffff) is not false, ignoring ERC-165"})
+		return
+	}
+
+	for _, standard := range priority {
+		id, ok := interfaceIDs[standard]
+		if !ok {
+			continue
+		}
+
+		supported, err := checker.SupportInterface(ctx, address, id)
+		if err != nil || !supported {
+			continue
+		}
+
+		detection.Scores[standard] += erc165Weight
+		detection.Evidence = append(detection.Evidence, Evidence{
+			Source:   SourceERC165,
+			Standard: standard,
+			Detail:   fmt.Sprintf("supportsInterface(0x%x) returned true", id),
+			Weight:   erc165Weight,
+		})
+	}
+}
+
+func scoreSelectors(code []byte, detection *Detection) {
+	found := utils.ExtractSelectors(code)
+
+	for _, standard := range priority {
+		var matched []string
+		for _, signature := range signatures[standard] {
+			if _, ok := found[selector(signature)]; ok {
+				matched = append(matched, signature)
+			}
+		}
+
+		if len(matched) == 0 {
+			continue
+		}
+
+		weight := selectorWeight * float64(len(matched)) / float64(len(signatures[standard]))
+		detection.Scores[standard] += weight
+		detection.Evidence = append(detection.Evidence, Evidence{
+			Source:   SourceSelector,
+			Standard: standard,
+			Detail:   fmt.Sprintf("%d/%d selectors in dispatcher: %s", len(matched), len(signatures[standard]), strings.Join(matched, ", ")),
+			Weight:   weight,
+		})
+	}
+}
+
+func (d *impl) runProbes(ctx context.Context, address string, detection *Detection) {
+	to := common.HexToAddress(address)
+
+	for _, p := range probes {
+		sel := selector(p.signature)
+		output, err := d.provider.CallContract(ctx, ethereum.CallMsg{
+			To:   &to,
+			Data: append(sel[:], p.args...),
+		}, nil)
+
+		succeeded := err == nil && len(output) >= 32
+		if succeeded == p.expectFailure {
+			continue
+		}
+
+		detail := fmt.Sprintf("%s returned a word", p.signature)
+		if p.expectFailure {
+			detail = fmt.Sprintf("%s is not implemented", p.signature)
+		}
+
+		detection.Scores[p.standard] += p.weight
+		detection.Evidence = append(detection.Evidence, Evidence{
+			Source:   SourceProbe,
+			Standard: p.standard,
+			Detail:   detail,
+			Weight:   p.weight,
+		})
+	}
+}
+
+func selector(signature string) [4]byte {
+	var sel [4]byte
+	copy(sel[:], crypto.Keccak256([]byte(signature))[:4])
+	return sel
+}
+
+func word(v *big.Int) []byte {
+	if v == nil {
+		return make([]byte, 32)
+	}
+	return common.LeftPadBytes(v.Bytes(), 32)
+}
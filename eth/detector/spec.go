@@ -0,0 +1,47 @@
+package detector
+
+import (
+	"context"
+)
+
+type Detector interface {
+	DetectStandard(ctx context.Context, address string) (*Detection, error)
+}
+
+type Standard string
+
+const (
+	StandardERC20   Standard = "ERC20"
+	StandardERC721  Standard = "ERC721"
+	StandardERC1155 Standard = "ERC1155"
+	StandardERC4626 Standard = "ERC4626"
+	StandardOther   Standard = "other"
+)
+
+type EvidenceSource string
+
+const (
+	SourceERC165   EvidenceSource = "erc165"
+	SourceProxy    EvidenceSource = "proxy"
+	SourceSelector EvidenceSource = "selector"
+	SourceProbe    EvidenceSource = "probe"
+)
+
+type Evidence struct {
+	Source   EvidenceSource `json:"source"`
+	Standard Standard       `json:"standard,omitempty"`
+	Detail   string         `json:"detail"`
+	Weight   float64        `json:"weight"`
+}
+
+type Detection struct {
+	Address string `json:"address"`
+	// Implementation is the address whose bytecode was analysed when Address is a proxy.
+	Implementation string   `json:"implementation,omitempty"`
+	Standard       Standard `json:"standard"`
+	// Confidence is in [0, 1]; for StandardOther it is the confidence that none of
+	// the known standards apply.
+	Confidence float64              `json:"confidence"`
+	Scores     map[Standard]float64 `json:"scores"`
+	Evidence   []Evidence           `json:"evidence"`
+}
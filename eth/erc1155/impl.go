@@ -2,21 +2,36 @@ package erc1155
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
+	"sort"
+	"strings"
 
 	"github.com/dtome123/go-bcwe3/eth/constants"
 	"github.com/dtome123/go-bcwe3/eth/contract"
 	"github.com/dtome123/go-bcwe3/eth/erc165"
+	"github.com/dtome123/go-bcwe3/eth/erc2981"
 	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/scanner"
+	"github.com/dtome123/go-bcwe3/eth/signer"
+	"github.com/dtome123/go-bcwe3/eth/types"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 )
 
+var (
+	ErrLengthMismatch = errors.New("ids and amounts/accounts must have the same length")
+)
+
 type impl struct {
 	provider provider.Provider
+	scanner  scanner.LogScanner
 	address  string
 	contract.Contract
 	erc165.ERC165
+	erc2981.ERC2981
 }
 
 func New(
@@ -32,24 +47,198 @@ func New(
 	if err != nil {
 		return nil, err
 	}
+
+	royalties, err := erc2981.New(address, provider)
+	if err != nil {
+		return nil, err
+	}
 	return &impl{
 		provider: provider,
+		scanner:  scanner.NewLogScanner(provider, nil),
 		address:  address,
 		Contract: contract,
 		ERC165:   erc165,
+		ERC2981:  royalties,
 	}, nil
 }
 
 func (i *impl) IsERC1155(ctx context.Context, contractAddr string) (bool, error) {
-	// ERC-1155 interfaceId is "0xd9b67a26"
-	interfaceIdBytes := [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+	return i.SupportInterface(ctx, contractAddr, constants.ERC1155InterfaceID)
+}
+
+// GetOwnerTokens rebuilds per-(holder, id) balances by replaying TransferSingle and
+// TransferBatch events. Mints come from and burns go to the zero address, which is
+// never reported as a holder.
+func (i *impl) GetOwnerTokens(ctx context.Context, filter *OwnerFilter) ([]*types.NFTBalance, error) {
+	if filter == nil {
+		filter = &OwnerFilter{}
+	}
+
+	contractABI := i.Contract.ABI()
+	singleID := contractABI.Events["TransferSingle"].ID
+	batchID := contractABI.Events["TransferBatch"].ID
+	eventIDs := []common.Hash{singleID, batchID}
+
+	query := ethereum.FilterQuery{
+		FromBlock: filter.FromBlock,
+		ToBlock:   filter.ToBlock,
+		Addresses: []common.Address{common.HexToAddress(i.address)},
+		Topics:    [][]common.Hash{eventIDs},
+	}
+
+	var logs []types.Log
+	if filter.Holder == "" {
+		found, err := i.scanner.FilterLogs(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		logs = found
+	} else {
+		// both scans must cover the same range, so latest is resolved once
+		if query.ToBlock == nil {
+			head, err := i.provider.BlockNumber(ctx)
+			if err != nil {
+				return nil, err
+			}
+			query.ToBlock = new(big.Int).SetUint64(head)
+		}
+
+		// the holder is either the sender (topic 2) or the receiver (topic 3)
+		holderTopic := common.BytesToHash(common.HexToAddress(filter.Holder).Bytes())
+
+		sent := query
+		sent.Topics = [][]common.Hash{eventIDs, nil, {holderTopic}}
+		received := query
+		received.Topics = [][]common.Hash{eventIDs, nil, nil, {holderTopic}}
+
+		seen := make(map[string]bool)
+		for _, q := range []ethereum.FilterQuery{sent, received} {
+			found, err := i.scanner.FilterLogs(ctx, q)
+			if err != nil {
+				return nil, err
+			}
+			for _, log := range found {
+				key := fmt.Sprintf("%s:%d", log.TxHash, log.Index)
+				if !seen[key] {
+					seen[key] = true
+					logs = append(logs, log)
+				}
+			}
+		}
+		sort.Sort(types.LogHeap(logs))
+	}
+
+	type holding struct {
+		holder  common.Address
+		tokenId *big.Int
+	}
+
+	balances := make(map[string]*big.Int)
+	holdings := make(map[string]holding)
+
+	move := func(from common.Address, to common.Address, id *big.Int, value *big.Int) {
+		if filter.TokenId != nil && filter.TokenId.Cmp(id) != 0 {
+			return
+		}
 
-	return i.SupportInterface(ctx, contractAddr, interfaceIdBytes)
+		for _, side := range []struct {
+			account common.Address
+			sign    int
+		}{{from, -1}, {to, 1}} {
+			if side.account == (common.Address{}) {
+				continue
+			}
+
+			key := side.account.Hex() + ":" + id.String()
+			balance, ok := balances[key]
+			if !ok {
+				balance = new(big.Int)
+				balances[key] = balance
+				holdings[key] = holding{holder: side.account, tokenId: id}
+			}
+
+			if side.sign < 0 {
+				balance.Sub(balance, value)
+			} else {
+				balance.Add(balance, value)
+			}
+		}
+	}
+
+	for _, log := range logs {
+		if log.Removed || len(log.Topics) != 4 {
+			continue
+		}
+
+		from := common.BytesToAddress(log.Topics[2].Bytes())
+		to := common.BytesToAddress(log.Topics[3].Bytes())
+
+		switch log.Topics[0] {
+		case singleID:
+			values, err := contractABI.Unpack("TransferSingle", log.Data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode TransferSingle in %s: %v", log.TxHash, err)
+			}
+			move(from, to, values[0].(*big.Int), values[1].(*big.Int))
+		case batchID:
+			values, err := contractABI.Unpack("TransferBatch", log.Data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode TransferBatch in %s: %v", log.TxHash, err)
+			}
+			ids, amounts := values[0].([]*big.Int), values[1].([]*big.Int)
+			if len(ids) != len(amounts) {
+				return nil, fmt.Errorf("%w in TransferBatch %s", ErrLengthMismatch, log.TxHash)
+			}
+			for idx := range ids {
+				move(from, to, ids[idx], amounts[idx])
+			}
+		}
+	}
+
+	var holder common.Address
+	if filter.Holder != "" {
+		holder = common.HexToAddress(filter.Holder)
+	}
+
+	out := make([]*types.NFTBalance, 0, len(balances))
+	for key, balance := range balances {
+		h := holdings[key]
+		if balance.Sign() <= 0 || (filter.Holder != "" && h.holder != holder) {
+			continue
+		}
+
+		out = append(out, &types.NFTBalance{
+			Token: types.NFT{
+				ContractAddress: i.address,
+				TokenId:         h.tokenId.String(),
+				Standard:        types.ERC1155,
+			},
+			Owner:   h.holder.Hex(),
+			Balance: balance,
+		})
+	}
+
+	sort.Slice(out, func(a, b int) bool {
+		if out[a].Owner != out[b].Owner {
+			return out[a].Owner < out[b].Owner
+		}
+		// token ids are decimal strings, compare numerically
+		if len(out[a].Token.TokenId) != len(out[b].Token.TokenId) {
+			return len(out[a].Token.TokenId) < len(out[b].Token.TokenId)
+		}
+		return out[a].Token.TokenId < out[b].Token.TokenId
+	})
+
+	return out, nil
 }
 
-func (i *impl) GetBalanceOf(ctx context.Context, account string) (*big.Int, error) {
+func (i *impl) BalanceOf(ctx context.Context, account string, id *big.Int) (*big.Int, error) {
+	return i.BalanceOfAt(ctx, account, id, nil)
+}
 
-	result, err := i.Contract.Call(context.Background(), "balanceOf", common.HexToAddress(account))
+// BalanceOfAt is BalanceOf at the block selected by opts.
+func (i *impl) BalanceOfAt(ctx context.Context, account string, id *big.Int, opts *contract.CallOpts) (*big.Int, error) {
+	result, err := i.Contract.CallWithOpts(ctx, opts, "balanceOf", common.HexToAddress(account), id)
 
 	if err != nil {
 		return nil, err
@@ -58,20 +247,107 @@ func (i *impl) GetBalanceOf(ctx context.Context, account string) (*big.Int, erro
 	return result.Index(0).AsBigInt()
 }
 
-func (i *impl) GetOwnerOf(ctx context.Context, tokenId *big.Int) (string, error) {
+func (i *impl) BalanceOfBatch(ctx context.Context, accounts []string, ids []*big.Int) ([]*big.Int, error) {
+	return i.BalanceOfBatchAt(ctx, accounts, ids, nil)
+}
+
+// BalanceOfBatchAt is BalanceOfBatch at the block selected by opts.
+func (i *impl) BalanceOfBatchAt(ctx context.Context, accounts []string, ids []*big.Int, opts *contract.CallOpts) ([]*big.Int, error) {
+	if len(accounts) != len(ids) {
+		return nil, ErrLengthMismatch
+	}
 
-	result, err := i.Contract.Call(context.Background(), "ownerOf", tokenId)
+	addresses := make([]common.Address, len(accounts))
+	for idx, account := range accounts {
+		addresses[idx] = common.HexToAddress(account)
+	}
+
+	result, err := i.Contract.CallWithOpts(ctx, opts, "balanceOfBatch", addresses, ids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Index(0).AsBigInts()
+}
+
+func (i *impl) IsApprovedForAll(ctx context.Context, account string, operator string) (bool, error) {
+	return i.IsApprovedForAllAt(ctx, account, operator, nil)
+}
+
+// IsApprovedForAllAt is IsApprovedForAll at the block selected by opts.
+func (i *impl) IsApprovedForAllAt(ctx context.Context, account string, operator string, opts *contract.CallOpts) (bool, error) {
+	result, err := i.Contract.CallWithOpts(ctx, opts, "isApprovedForAll", common.HexToAddress(account), common.HexToAddress(operator))
+
+	if err != nil {
+		return false, err
+	}
+
+	return result.Index(0).AsBool()
+}
+
+// URI returns the metadata URI for id with the ERC-1155 {id} placeholder substituted
+// by the lowercase, 64 character hex encoding of id.
+func (i *impl) URI(ctx context.Context, id *big.Int) (string, error) {
+	return i.URIAt(ctx, id, nil)
+}
+
+// URIAt is URI at the block selected by opts.
+func (i *impl) URIAt(ctx context.Context, id *big.Int, opts *contract.CallOpts) (string, error) {
+	result, err := i.Contract.CallWithOpts(ctx, opts, "uri", id)
 
 	if err != nil {
 		return "", err
 	}
 
-	return result.Index(0).AsString()
+	uri, err := result.Index(0).AsString()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ReplaceAll(uri, "{id}", fmt.Sprintf("%064x", id)), nil
+}
+
+func (i *impl) SafeTransferFrom(ctx context.Context, signer signer.Signer, from string, to string, id *big.Int, amount *big.Int, data []byte, opts *contract.TransactOpts) (*types.Tx, error) {
+	if err := contract.RequireAddresses(from, to); err != nil {
+		return nil, err
+	}
+	if data == nil {
+		data = []byte{}
+	}
+
+	return i.Contract.TransactWithSigner(ctx, signer, opts, "safeTransferFrom", common.HexToAddress(from), common.HexToAddress(to), id, amount, data)
+}
+
+func (i *impl) SafeBatchTransferFrom(ctx context.Context, signer signer.Signer, from string, to string, ids []*big.Int, amounts []*big.Int, data []byte, opts *contract.TransactOpts) (*types.Tx, error) {
+	if err := contract.RequireAddresses(from, to); err != nil {
+		return nil, err
+	}
+	if len(ids) != len(amounts) {
+		return nil, ErrLengthMismatch
+	}
+	if data == nil {
+		data = []byte{}
+	}
+
+	return i.Contract.TransactWithSigner(ctx, signer, opts, "safeBatchTransferFrom", common.HexToAddress(from), common.HexToAddress(to), ids, amounts, data)
+}
+
+func (i *impl) SetApprovalForAll(ctx context.Context, signer signer.Signer, operator string, approved bool, opts *contract.TransactOpts) (*types.Tx, error) {
+	if err := contract.RequireAddresses(operator); err != nil {
+		return nil, err
+	}
+
+	return i.Contract.TransactWithSigner(ctx, signer, opts, "setApprovalForAll", common.HexToAddress(operator), approved)
 }
 
 func (i *impl) GetName(ctx context.Context) (string, error) {
+	return i.GetNameAt(ctx, nil)
+}
 
-	result, err := i.Contract.Call(context.Background(), "name")
+// GetNameAt is GetName at the block selected by opts.
+func (i *impl) GetNameAt(ctx context.Context, opts *contract.CallOpts) (string, error) {
+	result, err := i.Contract.CallWithOpts(ctx, opts, "name")
 
 	if err != nil {
 		return "", err
@@ -81,8 +357,12 @@ func (i *impl) GetName(ctx context.Context) (string, error) {
 }
 
 func (i *impl) GetSymbol(ctx context.Context) (string, error) {
+	return i.GetSymbolAt(ctx, nil)
+}
 
-	result, err := i.Contract.Call(context.Background(), "symbol")
+// GetSymbolAt is GetSymbol at the block selected by opts.
+func (i *impl) GetSymbolAt(ctx context.Context, opts *contract.CallOpts) (string, error) {
+	result, err := i.Contract.CallWithOpts(ctx, opts, "symbol")
 
 	if err != nil {
 		return "", err
@@ -5,13 +5,39 @@ import (
 	"math/big"
 
 	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/erc2981"
+	"github.com/dtome123/go-bcwe3/eth/signer"
+	"github.com/dtome123/go-bcwe3/eth/types"
 )
 
 type ERC1155 interface {
 	contract.Contract
+	erc2981.ERC2981
 	IsERC1155(ctx context.Context, contractAddr string) (bool, error)
-	GetBalanceOf(ctx context.Context, account string) (*big.Int, error)
-	GetOwnerOf(ctx context.Context, tokenId *big.Int) (string, error)
+	GetOwnerTokens(ctx context.Context, filter *OwnerFilter) ([]*types.NFTBalance, error)
+	BalanceOf(ctx context.Context, account string, id *big.Int) (*big.Int, error)
+	BalanceOfAt(ctx context.Context, account string, id *big.Int, opts *contract.CallOpts) (*big.Int, error)
+	BalanceOfBatch(ctx context.Context, accounts []string, ids []*big.Int) ([]*big.Int, error)
+	BalanceOfBatchAt(ctx context.Context, accounts []string, ids []*big.Int, opts *contract.CallOpts) ([]*big.Int, error)
+	IsApprovedForAll(ctx context.Context, account string, operator string) (bool, error)
+	IsApprovedForAllAt(ctx context.Context, account string, operator string, opts *contract.CallOpts) (bool, error)
+	URI(ctx context.Context, id *big.Int) (string, error)
+	URIAt(ctx context.Context, id *big.Int, opts *contract.CallOpts) (string, error)
+	SafeTransferFrom(ctx context.Context, signer signer.Signer, from string, to string, id *big.Int, amount *big.Int, data []byte, opts *contract.TransactOpts) (*types.Tx, error)
+	SafeBatchTransferFrom(ctx context.Context, signer signer.Signer, from string, to string, ids []*big.Int, amounts []*big.Int, data []byte, opts *contract.TransactOpts) (*types.Tx, error)
+	SetApprovalForAll(ctx context.Context, signer signer.Signer, operator string, approved bool, opts *contract.TransactOpts) (*types.Tx, error)
+	// GetName and GetSymbol are optional in ERC-1155 and fail on collections that do not implement them.
 	GetName(ctx context.Context) (string, error)
+	GetNameAt(ctx context.Context, opts *contract.CallOpts) (string, error)
 	GetSymbol(ctx context.Context) (string, error)
+	GetSymbolAt(ctx context.Context, opts *contract.CallOpts) (string, error)
+}
+
+// OwnerFilter scopes GetOwnerTokens; empty fields match everything.
+type OwnerFilter struct {
+	Holder  string
+	TokenId *big.Int
+	// FromBlock and ToBlock bound the scanned range, nil ToBlock means latest.
+	FromBlock *big.Int
+	ToBlock   *big.Int
 }
@@ -2,14 +2,20 @@ package erc165
 
 import (
 	"context"
+	"math/big"
+	"strings"
 
 	"github.com/dtome123/go-bcwe3/eth/constants"
 	"github.com/dtome123/go-bcwe3/eth/contract"
 	"github.com/dtome123/go-bcwe3/eth/provider"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 type impl struct {
 	provider provider.Provider
+	address  string
 	contract.Contract
 }
 
@@ -23,17 +29,39 @@ func New(address string, provider provider.Provider) (ERC165, error) {
 
 	return &impl{
 		provider: provider,
+		address:  address,
 		Contract: contract,
 	}, nil
 }
 
+// SupportInterface queries contractAddr, or the wrapped contract when contractAddr is empty.
 func (i *impl) SupportInterface(ctx context.Context, contractAddr string, interfaceIdBytes [4]byte) (bool, error) {
 
-	result, err := i.Contract.Call(context.Background(), "supportsInterface", interfaceIdBytes)
+	contractAddr = strings.TrimSpace(contractAddr)
+	if contractAddr == "" || common.HexToAddress(contractAddr) == common.HexToAddress(i.address) {
+		result, err := i.Contract.Call(ctx, "supportsInterface", interfaceIdBytes)
+
+		if err != nil {
+			return false, err
+		}
+
+		return result.Index(0).AsBool()
+	}
+
+	input, err := i.Contract.ABI().Pack("supportsInterface", interfaceIdBytes)
+	if err != nil {
+		return false, err
+	}
 
+	to := common.HexToAddress(contractAddr)
+	output, err := i.provider.CallContract(ctx, ethereum.CallMsg{To: &to, Data: input}, nil)
 	if err != nil {
 		return false, err
 	}
 
-	return result.Index(0).AsBool()
+	if len(output) < 32 {
+		return false, nil
+	}
+
+	return new(big.Int).SetBytes(output[:32]).Sign() != 0, nil
 }
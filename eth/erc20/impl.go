@@ -2,17 +2,33 @@ package erc20
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
-	"strings"
 
 	"github.com/dtome123/go-bcwe3/eth/constants"
 	"github.com/dtome123/go-bcwe3/eth/contract"
 	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/proxy"
+	"github.com/dtome123/go-bcwe3/eth/revert"
+	"github.com/dtome123/go-bcwe3/eth/signer"
 	"github.com/dtome123/go-bcwe3/eth/types"
+	"github.com/dtome123/go-bcwe3/eth/utils"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"golang.org/x/sync/errgroup"
 )
 
+const balanceParallelism = 8
+
+var (
+	ErrTransferReturnedFalse = errors.New("token transfer returned false")
+	ErrTransactionReverted   = errors.New("transaction reverted")
+	ErrTransferNotObserved   = errors.New("no matching Transfer event in receipt")
+	ErrAllowanceUnderflow    = errors.New("allowance decrease exceeds current allowance")
+	ErrNilAmount             = errors.New("amount is required")
+)
+
 type impl struct {
 	provider provider.Provider
 	address  string
@@ -24,37 +40,28 @@ func (i *impl) Address() string {
 }
 
 func (i *impl) Name() (string, error) {
-	result, err := i.Contract.Call(context.Background(), "name")
-
-	if err != nil {
-		return "", err
-	}
-
-	return result.Index(0).AsString()
+	return i.NameAt(context.Background(), nil)
 }
 
 func (i *impl) Symbol() (string, error) {
-	result, err := i.Contract.Call(context.Background(), "symbol")
-
-	if err != nil {
-		return "", err
-	}
-
-	return result.Index(0).AsString()
+	return i.SymbolAt(context.Background(), nil)
 }
 
 func (i *impl) Decimals() (uint8, error) {
-	result, err := i.Contract.Call(context.Background(), "decimals")
+	return i.DecimalsAt(context.Background(), nil)
+}
 
-	if err != nil {
-		return 0, err
-	}
+func (i *impl) TotalSupply() (*big.Int, error) {
+	return i.TotalSupplyAt(context.Background(), nil)
+}
 
-	return result.Index(0).AsUnit8()
+func (i *impl) BalanceOf(account string) (*big.Int, error) {
+	return i.BalanceOfAt(context.Background(), account, nil)
 }
 
-func (i *impl) TotalSupply() (*big.Int, error) {
-	result, err := i.Contract.Call(context.Background(), "totalSupply")
+// BalanceOfAt reads the balance of account at the block selected by opts.
+func (i *impl) BalanceOfAt(ctx context.Context, account string, opts *contract.CallOpts) (*big.Int, error) {
+	result, err := i.Contract.CallWithOpts(ctx, opts, "balanceOf", common.HexToAddress(account))
 
 	if err != nil {
 		return nil, err
@@ -63,17 +70,43 @@ func (i *impl) TotalSupply() (*big.Int, error) {
 	return result.Index(0).AsBigInt()
 }
 
-func (i *impl) BalanceOf(account string) (*big.Int, error) {
-	result, err := i.Contract.Call(context.Background(), "balanceOf", common.HexToAddress(account))
+// BalancesOf reads the balances of accounts concurrently at the block selected
+// by opts, keyed by account as given.
+func (i *impl) BalancesOf(ctx context.Context, accounts []string, opts *contract.CallOpts) (map[string]*big.Int, error) {
+	balances := make([]*big.Int, len(accounts))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(balanceParallelism)
+
+	for idx, account := range accounts {
+		g.Go(func() error {
+			balance, err := i.BalanceOfAt(gctx, account, opts)
+			if err != nil {
+				return err
+			}
+			balances[idx] = balance
+			return nil
+		})
+	}
 
-	if err != nil {
+	if err := g.Wait(); err != nil {
 		return nil, err
 	}
 
-	return result.Index(0).AsBigInt()
+	out := make(map[string]*big.Int, len(accounts))
+	for idx, account := range accounts {
+		out[account] = balances[idx]
+	}
+
+	return out, nil
 }
 
 func (i *impl) GetInfo(ctx context.Context) (*types.ERC20Token, error) {
+	return i.GetInfoAt(ctx, nil)
+}
+
+// GetInfoAt reads the token info at the block selected by opts.
+func (i *impl) GetInfoAt(ctx context.Context, opts *contract.CallOpts) (*types.ERC20Token, error) {
 	var (
 		name        string
 		symbol      string
@@ -81,29 +114,29 @@ func (i *impl) GetInfo(ctx context.Context) (*types.ERC20Token, error) {
 		totalSupply *big.Int
 	)
 
-	g, _ := errgroup.WithContext(ctx)
+	g, gctx := errgroup.WithContext(ctx)
 
 	g.Go(func() error {
 		var err error
-		name, err = i.Name()
+		name, err = i.NameAt(gctx, opts)
 		return err
 	})
 
 	g.Go(func() error {
 		var err error
-		symbol, err = i.Symbol()
+		symbol, err = i.SymbolAt(gctx, opts)
 		return err
 	})
 
 	g.Go(func() error {
 		var err error
-		decimals, err = i.Decimals()
+		decimals, err = i.DecimalsAt(gctx, opts)
 		return err
 	})
 
 	g.Go(func() error {
 		var err error
-		totalSupply, err = i.TotalSupply()
+		totalSupply, err = i.TotalSupplyAt(gctx, opts)
 		return err
 	})
 
@@ -120,9 +153,82 @@ func (i *impl) GetInfo(ctx context.Context) (*types.ERC20Token, error) {
 	}, nil
 }
 
+// Snapshot pins the block selected by opts (latest when nil) to its hash and
+// reads the token info and the balances of accounts at that single block.
+func (i *impl) Snapshot(ctx context.Context, accounts []string, opts *contract.CallOpts) (*types.ERC20Snapshot, error) {
+	pinned, header, err := contract.PinBlock(ctx, i.provider, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := i.GetInfoAt(ctx, pinned)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := i.BalancesOf(ctx, accounts, pinned)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ERC20Snapshot{
+		Token:       token,
+		BlockNumber: header.Number,
+		BlockHash:   header.Origin.Hash().Hex(),
+		Balances:    balances,
+	}, nil
+}
+
+func (i *impl) NameAt(ctx context.Context, opts *contract.CallOpts) (string, error) {
+	result, err := i.Contract.CallWithOpts(ctx, opts, "name")
+
+	if err != nil {
+		return "", err
+	}
+
+	return result.Index(0).AsString()
+}
+
+func (i *impl) SymbolAt(ctx context.Context, opts *contract.CallOpts) (string, error) {
+	result, err := i.Contract.CallWithOpts(ctx, opts, "symbol")
+
+	if err != nil {
+		return "", err
+	}
+
+	return result.Index(0).AsString()
+}
+
+func (i *impl) DecimalsAt(ctx context.Context, opts *contract.CallOpts) (uint8, error) {
+	result, err := i.Contract.CallWithOpts(ctx, opts, "decimals")
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Index(0).AsUnit8()
+}
+
+func (i *impl) TotalSupplyAt(ctx context.Context, opts *contract.CallOpts) (*big.Int, error) {
+	result, err := i.Contract.CallWithOpts(ctx, opts, "totalSupply")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Index(0).AsBigInt()
+}
+
+// IsPossiblyERC20 checks the dispatcher of the contract, or of its implementation when
+// the address is a proxy, for the ERC-20 function selectors.
 func (i *impl) IsPossiblyERC20(ctx context.Context) (bool, error) {
 
-	bytecode, err := i.provider.CodeAt(ctx, i.address, nil)
+	resolution, err := proxy.NewResolver(i.provider).Resolve(ctx, i.address, nil)
+	if err != nil {
+		return false, err
+	}
+
+	bytecode, err := i.provider.CodeAt(ctx, resolution.Implementation, nil)
 	if err != nil {
 		return false, err
 	}
@@ -130,11 +236,14 @@ func (i *impl) IsPossiblyERC20(ctx context.Context) (bool, error) {
 		return false, nil
 	}
 
-	hexCode := strings.ToLower(common.Bytes2Hex(bytecode))
+	found := utils.ExtractSelectors(bytecode)
 	matched := []string{}
 
 	for method, selector := range constants.ERC20Selectors {
-		if strings.Contains(hexCode, selector) {
+		var sel [4]byte
+		copy(sel[:], common.FromHex(selector))
+
+		if _, ok := found[sel]; ok {
 			matched = append(matched, method)
 		}
 	}
@@ -142,9 +251,237 @@ func (i *impl) IsPossiblyERC20(ctx context.Context) (bool, error) {
 	return len(matched) == len(constants.ERC20Selectors), nil
 }
 
+func (i *impl) Allowance(ctx context.Context, owner string, spender string) (*big.Int, error) {
+	return i.AllowanceAt(ctx, owner, spender, nil)
+}
+
+// AllowanceAt reads the allowance of spender over owner's tokens at the block selected by opts.
+func (i *impl) AllowanceAt(ctx context.Context, owner string, spender string, opts *contract.CallOpts) (*big.Int, error) {
+	result, err := i.Contract.CallWithOpts(ctx, opts, "allowance", common.HexToAddress(owner), common.HexToAddress(spender))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Index(0).AsBigInt()
+}
+
+func (i *impl) Transfer(ctx context.Context, signer signer.Signer, to string, amount *big.Int, opts *contract.TransactOpts) (*types.Tx, error) {
+	if err := contract.RequireAddresses(to); err != nil {
+		return nil, err
+	}
+	if amount == nil {
+		return nil, ErrNilAmount
+	}
+
+	return i.Contract.TransactWithSigner(ctx, signer, opts, "transfer", common.HexToAddress(to), amount)
+}
+
+func (i *impl) TransferFrom(ctx context.Context, signer signer.Signer, from string, to string, amount *big.Int, opts *contract.TransactOpts) (*types.Tx, error) {
+	if err := contract.RequireAddresses(from, to); err != nil {
+		return nil, err
+	}
+	if amount == nil {
+		return nil, ErrNilAmount
+	}
+
+	return i.Contract.TransactWithSigner(ctx, signer, opts, "transferFrom", common.HexToAddress(from), common.HexToAddress(to), amount)
+}
+
+func (i *impl) Approve(ctx context.Context, signer signer.Signer, spender string, amount *big.Int, opts *contract.TransactOpts) (*types.Tx, error) {
+	if err := contract.RequireAddresses(spender); err != nil {
+		return nil, err
+	}
+	if amount == nil {
+		return nil, ErrNilAmount
+	}
+
+	return i.Contract.TransactWithSigner(ctx, signer, opts, "approve", common.HexToAddress(spender), amount)
+}
+
+// SafeApprove sets the allowance of spender to amount. Tokens such as USDT revert when
+// changing a non-zero allowance to another non-zero value, so the allowance is reset to
+// zero first and that transaction is waited on before the final approve is sent.
+func (i *impl) SafeApprove(ctx context.Context, signer signer.Signer, spender string, amount *big.Int, opts *contract.TransactOpts) ([]*types.Tx, error) {
+	if err := contract.RequireAddresses(spender); err != nil {
+		return nil, err
+	}
+	if amount == nil {
+		return nil, ErrNilAmount
+	}
+
+	current, err := i.Allowance(ctx, signer.Address(), spender)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.Cmp(amount) == 0 {
+		return nil, nil
+	}
+
+	var txs []*types.Tx
+
+	if current.Sign() > 0 && amount.Sign() > 0 {
+		reset, err := i.Approve(ctx, signer, spender, big.NewInt(0), opts)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, reset)
+
+		receipt, err := i.provider.WaitMined(ctx, reset.Hash)
+		if err != nil {
+			return txs, err
+		}
+		if receipt.Status != 1 {
+			return txs, i.reverted(ctx, reset.Hash)
+		}
+
+		opts = nextNonce(opts)
+	}
+
+	tx, err := i.Approve(ctx, signer, spender, amount, opts)
+	if err != nil {
+		return txs, err
+	}
+
+	return append(txs, tx), nil
+}
+
+// IncreaseAllowance raises the allowance of spender by added, based on the current on-chain allowance.
+func (i *impl) IncreaseAllowance(ctx context.Context, signer signer.Signer, spender string, added *big.Int, opts *contract.TransactOpts) ([]*types.Tx, error) {
+	if err := contract.RequireAddresses(spender); err != nil {
+		return nil, err
+	}
+	if added == nil {
+		return nil, ErrNilAmount
+	}
+
+	current, err := i.Allowance(ctx, signer.Address(), spender)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.SafeApprove(ctx, signer, spender, new(big.Int).Add(current, added), opts)
+}
+
+// DecreaseAllowance lowers the allowance of spender by subtracted, based on the current on-chain allowance.
+func (i *impl) DecreaseAllowance(ctx context.Context, signer signer.Signer, spender string, subtracted *big.Int, opts *contract.TransactOpts) ([]*types.Tx, error) {
+	if err := contract.RequireAddresses(spender); err != nil {
+		return nil, err
+	}
+	if subtracted == nil {
+		return nil, ErrNilAmount
+	}
+
+	current, err := i.Allowance(ctx, signer.Address(), spender)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.Cmp(subtracted) < 0 {
+		return nil, ErrAllowanceUnderflow
+	}
+
+	return i.SafeApprove(ctx, signer, spender, new(big.Int).Sub(current, subtracted), opts)
+}
+
+// SafeTransfer sends a transfer and waits for it to be mined. Tokens that do not return
+// a value (USDT-style) are accepted; success is confirmed by the receipt status and a
+// matching Transfer event emitted by the token.
+func (i *impl) SafeTransfer(ctx context.Context, signer signer.Signer, to string, amount *big.Int, opts *contract.TransactOpts) (*types.Receipt, error) {
+	if err := contract.RequireAddresses(to); err != nil {
+		return nil, err
+	}
+	if amount == nil {
+		return nil, ErrNilAmount
+	}
+
+	tokenABI := i.Contract.ABI()
+
+	input, err := tokenABI.Pack("transfer", common.HexToAddress(to), amount)
+	if err != nil {
+		return nil, err
+	}
+
+	token := common.HexToAddress(i.address)
+	output, err := i.provider.CallContract(ctx, ethereum.CallMsg{
+		From: common.HexToAddress(signer.Address()),
+		To:   &token,
+		Data: input,
+	}, nil)
+	if err != nil {
+		return nil, revert.Wrap(err, tokenABI)
+	}
+
+	if len(output) > 0 {
+		values, err := tokenABI.Unpack("transfer", output)
+		if err != nil {
+			return nil, err
+		}
+		if ok, _ := values[0].(bool); !ok {
+			return nil, ErrTransferReturnedFalse
+		}
+	}
+
+	tx, err := i.Transfer(ctx, signer, to, amount, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := i.provider.WaitMined(ctx, tx.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if receipt.Status != 1 {
+		return receipt, i.reverted(ctx, tx.Hash)
+	}
+
+	if !hasTransferLog(receipt, token, tokenABI.Events["Transfer"].ID, common.HexToAddress(signer.Address()), common.HexToAddress(to), amount) {
+		return receipt, ErrTransferNotObserved
+	}
+
+	return receipt, nil
+}
+
+func hasTransferLog(receipt *types.Receipt, token common.Address, eventID common.Hash, from common.Address, to common.Address, amount *big.Int) bool {
+	for _, log := range receipt.Logs {
+		if common.HexToAddress(log.Address) != token || len(log.Topics) != 3 {
+			continue
+		}
+
+		if log.Topics[0] == eventID &&
+			common.BytesToAddress(log.Topics[1].Bytes()) == from &&
+			common.BytesToAddress(log.Topics[2].Bytes()) == to &&
+			new(big.Int).SetBytes(log.Data).Cmp(amount) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func nextNonce(opts *contract.TransactOpts) *contract.TransactOpts {
+	if opts == nil || opts.Nonce == nil {
+		return opts
+	}
+
+	next := *opts
+	nonce := *opts.Nonce + 1
+	next.Nonce = &nonce
+
+	return &next
+}
+
 func New(address string, provider provider.Provider) (ERC20, error) {
+	return NewWithABI(address, provider, constants.ERC20ABI)
+}
+
+// NewWithABI binds the ERC-20 wrapper with an ABI that is a superset of ERC-20,
+// letting token extensions reuse it while calling their own methods through Call.
+func NewWithABI(address string, provider provider.Provider, abiData string) (ERC20, error) {
 
-	contract, err := contract.NewContract(provider, address, constants.ERC20ABI)
+	contract, err := contract.NewContract(provider, address, abiData)
 
 	if err != nil {
 		return nil, err
@@ -152,6 +489,16 @@ func New(address string, provider provider.Provider) (ERC20, error) {
 
 	return &impl{
 		provider: provider,
+		address:  address,
 		Contract: contract,
 	}, nil
 }
+
+// reverted builds the error for a mined transaction that failed, including the
+// decoded revert reason when the failure can be replayed.
+func (i *impl) reverted(ctx context.Context, txHash string) error {
+	if reason := i.Contract.ReplayTransaction(ctx, txHash); reason != nil {
+		return fmt.Errorf("%w: %s: %w", ErrTransactionReverted, txHash, reason)
+	}
+	return fmt.Errorf("%w: %s", ErrTransactionReverted, txHash)
+}
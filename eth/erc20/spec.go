@@ -5,17 +5,35 @@ import (
 	"math/big"
 
 	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/signer"
 	"github.com/dtome123/go-bcwe3/eth/types"
 )
 
 type ERC20 interface {
 	contract.Contract
 	GetInfo(ctx context.Context) (*types.ERC20Token, error)
+	GetInfoAt(ctx context.Context, opts *contract.CallOpts) (*types.ERC20Token, error)
 	IsPossiblyERC20(ctx context.Context) (bool, error)
 	Address() string
 	Name() (string, error)
+	NameAt(ctx context.Context, opts *contract.CallOpts) (string, error)
 	Symbol() (string, error)
+	SymbolAt(ctx context.Context, opts *contract.CallOpts) (string, error)
 	Decimals() (uint8, error)
+	DecimalsAt(ctx context.Context, opts *contract.CallOpts) (uint8, error)
 	TotalSupply() (*big.Int, error)
+	TotalSupplyAt(ctx context.Context, opts *contract.CallOpts) (*big.Int, error)
 	BalanceOf(account string) (*big.Int, error)
+	BalanceOfAt(ctx context.Context, account string, opts *contract.CallOpts) (*big.Int, error)
+	BalancesOf(ctx context.Context, accounts []string, opts *contract.CallOpts) (map[string]*big.Int, error)
+	Snapshot(ctx context.Context, accounts []string, opts *contract.CallOpts) (*types.ERC20Snapshot, error)
+	Allowance(ctx context.Context, owner string, spender string) (*big.Int, error)
+	AllowanceAt(ctx context.Context, owner string, spender string, opts *contract.CallOpts) (*big.Int, error)
+	Transfer(ctx context.Context, signer signer.Signer, to string, amount *big.Int, opts *contract.TransactOpts) (*types.Tx, error)
+	TransferFrom(ctx context.Context, signer signer.Signer, from string, to string, amount *big.Int, opts *contract.TransactOpts) (*types.Tx, error)
+	Approve(ctx context.Context, signer signer.Signer, spender string, amount *big.Int, opts *contract.TransactOpts) (*types.Tx, error)
+	SafeApprove(ctx context.Context, signer signer.Signer, spender string, amount *big.Int, opts *contract.TransactOpts) ([]*types.Tx, error)
+	IncreaseAllowance(ctx context.Context, signer signer.Signer, spender string, added *big.Int, opts *contract.TransactOpts) ([]*types.Tx, error)
+	DecreaseAllowance(ctx context.Context, signer signer.Signer, spender string, subtracted *big.Int, opts *contract.TransactOpts) ([]*types.Tx, error)
+	SafeTransfer(ctx context.Context, signer signer.Signer, to string, amount *big.Int, opts *contract.TransactOpts) (*types.Receipt, error)
 }
@@ -0,0 +1,86 @@
+package erc2981
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/dtome123/go-bcwe3/eth/constants"
+	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/erc165"
+	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/types"
+)
+
+var (
+	ErrRoyaltiesUnsupported = errors.New("contract does not support ERC-2981 royalties")
+)
+
+type impl struct {
+	provider provider.Provider
+	address  string
+	contract contract.Contract
+	erc165   erc165.ERC165
+}
+
+func New(address string, provider provider.Provider) (ERC2981, error) {
+
+	erc165, err := erc165.New(address, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := contract.NewContract(provider, address, constants.ERC2981ABI)
+	if err != nil {
+		return nil, err
+	}
+
+	return &impl{
+		provider: provider,
+		address:  address,
+		contract: contract,
+		erc165:   erc165,
+	}, nil
+}
+
+func (i *impl) SupportsRoyalties(ctx context.Context) (bool, error) {
+	return i.erc165.SupportInterface(ctx, i.address, constants.ERC2981InterfaceID)
+}
+
+// RoyaltyInfo returns the receiver and amount owed for selling tokenId at salePrice.
+func (i *impl) RoyaltyInfo(ctx context.Context, tokenId *big.Int, salePrice *big.Int) (*types.NFTRoyalty, error) {
+	return i.RoyaltyInfoAt(ctx, tokenId, salePrice, nil)
+}
+
+// RoyaltyInfoAt is RoyaltyInfo at the block selected by opts.
+func (i *impl) RoyaltyInfoAt(ctx context.Context, tokenId *big.Int, salePrice *big.Int, opts *contract.CallOpts) (*types.NFTRoyalty, error) {
+	supported, err := i.SupportsRoyalties(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !supported {
+		return nil, ErrRoyaltiesUnsupported
+	}
+
+	result, err := i.contract.CallWithOpts(ctx, opts, "royaltyInfo", tokenId, salePrice)
+	if err != nil {
+		return nil, err
+	}
+
+	receiver, err := result.Index(0).AsAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := result.Index(1).AsBigInt()
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.NFTRoyalty{
+		TokenId:   tokenId.String(),
+		SalePrice: salePrice,
+		Receiver:  receiver.Hex(),
+		Amount:    amount,
+	}, nil
+}
@@ -0,0 +1,15 @@
+package erc2981
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/types"
+)
+
+type ERC2981 interface {
+	SupportsRoyalties(ctx context.Context) (bool, error)
+	RoyaltyInfo(ctx context.Context, tokenId *big.Int, salePrice *big.Int) (*types.NFTRoyalty, error)
+	RoyaltyInfoAt(ctx context.Context, tokenId *big.Int, salePrice *big.Int, opts *contract.CallOpts) (*types.NFTRoyalty, error)
+}
@@ -0,0 +1,263 @@
+package erc4626
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+
+	"github.com/dtome123/go-bcwe3/eth/constants"
+	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/erc20"
+	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/signer"
+	"github.com/dtome123/go-bcwe3/eth/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const secondsPerYear = 365 * 24 * 60 * 60
+
+var (
+	ErrInvalidBlockRange = errors.New("to block must be after from block")
+	ErrZeroSharePrice    = errors.New("share price is zero at the start block")
+)
+
+type impl struct {
+	provider provider.Provider
+	address  string
+	erc20.ERC20
+}
+
+func New(address string, provider provider.Provider) (ERC4626, error) {
+
+	token, err := erc20.NewWithABI(address, provider, constants.ERC4626ABI)
+	if err != nil {
+		return nil, err
+	}
+
+	return &impl{
+		provider: provider,
+		address:  address,
+		ERC20:    token,
+	}, nil
+}
+
+func (i *impl) Asset(ctx context.Context) (string, error) {
+	return i.AssetAt(ctx, nil)
+}
+
+// AssetAt is Asset at the block selected by opts.
+func (i *impl) AssetAt(ctx context.Context, opts *contract.CallOpts) (string, error) {
+	result, err := i.ERC20.CallWithOpts(ctx, opts, "asset")
+
+	if err != nil {
+		return "", err
+	}
+
+	asset, err := result.Index(0).AsAddress()
+	if err != nil {
+		return "", err
+	}
+
+	return asset.Hex(), nil
+}
+
+func (i *impl) TotalAssets(ctx context.Context) (*big.Int, error) {
+	return i.TotalAssetsAt(ctx, nil)
+}
+
+// TotalAssetsAt is TotalAssets at the block selected by opts.
+func (i *impl) TotalAssetsAt(ctx context.Context, opts *contract.CallOpts) (*big.Int, error) {
+	return i.callBigInt(ctx, opts, "totalAssets")
+}
+
+func (i *impl) ConvertToShares(ctx context.Context, assets *big.Int) (*big.Int, error) {
+	return i.ConvertToSharesAt(ctx, assets, nil)
+}
+
+// ConvertToSharesAt is ConvertToShares at the block selected by opts.
+func (i *impl) ConvertToSharesAt(ctx context.Context, assets *big.Int, opts *contract.CallOpts) (*big.Int, error) {
+	return i.callBigInt(ctx, opts, "convertToShares", assets)
+}
+
+func (i *impl) ConvertToAssets(ctx context.Context, shares *big.Int) (*big.Int, error) {
+	return i.ConvertToAssetsAt(ctx, shares, nil)
+}
+
+// ConvertToAssetsAt is ConvertToAssets at the block selected by opts.
+func (i *impl) ConvertToAssetsAt(ctx context.Context, shares *big.Int, opts *contract.CallOpts) (*big.Int, error) {
+	return i.callBigInt(ctx, opts, "convertToAssets", shares)
+}
+
+func (i *impl) PreviewDeposit(ctx context.Context, assets *big.Int) (*big.Int, error) {
+	return i.PreviewDepositAt(ctx, assets, nil)
+}
+
+// PreviewDepositAt is PreviewDeposit at the block selected by opts.
+func (i *impl) PreviewDepositAt(ctx context.Context, assets *big.Int, opts *contract.CallOpts) (*big.Int, error) {
+	return i.callBigInt(ctx, opts, "previewDeposit", assets)
+}
+
+func (i *impl) PreviewMint(ctx context.Context, shares *big.Int) (*big.Int, error) {
+	return i.PreviewMintAt(ctx, shares, nil)
+}
+
+// PreviewMintAt is PreviewMint at the block selected by opts.
+func (i *impl) PreviewMintAt(ctx context.Context, shares *big.Int, opts *contract.CallOpts) (*big.Int, error) {
+	return i.callBigInt(ctx, opts, "previewMint", shares)
+}
+
+func (i *impl) PreviewWithdraw(ctx context.Context, assets *big.Int) (*big.Int, error) {
+	return i.PreviewWithdrawAt(ctx, assets, nil)
+}
+
+// PreviewWithdrawAt is PreviewWithdraw at the block selected by opts.
+func (i *impl) PreviewWithdrawAt(ctx context.Context, assets *big.Int, opts *contract.CallOpts) (*big.Int, error) {
+	return i.callBigInt(ctx, opts, "previewWithdraw", assets)
+}
+
+func (i *impl) PreviewRedeem(ctx context.Context, shares *big.Int) (*big.Int, error) {
+	return i.PreviewRedeemAt(ctx, shares, nil)
+}
+
+// PreviewRedeemAt is PreviewRedeem at the block selected by opts.
+func (i *impl) PreviewRedeemAt(ctx context.Context, shares *big.Int, opts *contract.CallOpts) (*big.Int, error) {
+	return i.callBigInt(ctx, opts, "previewRedeem", shares)
+}
+
+func (i *impl) MaxDeposit(ctx context.Context, receiver string) (*big.Int, error) {
+	return i.MaxDepositAt(ctx, receiver, nil)
+}
+
+// MaxDepositAt is MaxDeposit at the block selected by opts.
+func (i *impl) MaxDepositAt(ctx context.Context, receiver string, opts *contract.CallOpts) (*big.Int, error) {
+	return i.callBigInt(ctx, opts, "maxDeposit", common.HexToAddress(receiver))
+}
+
+func (i *impl) MaxMint(ctx context.Context, receiver string) (*big.Int, error) {
+	return i.MaxMintAt(ctx, receiver, nil)
+}
+
+// MaxMintAt is MaxMint at the block selected by opts.
+func (i *impl) MaxMintAt(ctx context.Context, receiver string, opts *contract.CallOpts) (*big.Int, error) {
+	return i.callBigInt(ctx, opts, "maxMint", common.HexToAddress(receiver))
+}
+
+func (i *impl) MaxWithdraw(ctx context.Context, owner string) (*big.Int, error) {
+	return i.MaxWithdrawAt(ctx, owner, nil)
+}
+
+// MaxWithdrawAt is MaxWithdraw at the block selected by opts.
+func (i *impl) MaxWithdrawAt(ctx context.Context, owner string, opts *contract.CallOpts) (*big.Int, error) {
+	return i.callBigInt(ctx, opts, "maxWithdraw", common.HexToAddress(owner))
+}
+
+func (i *impl) MaxRedeem(ctx context.Context, owner string) (*big.Int, error) {
+	return i.MaxRedeemAt(ctx, owner, nil)
+}
+
+// MaxRedeemAt is MaxRedeem at the block selected by opts.
+func (i *impl) MaxRedeemAt(ctx context.Context, owner string, opts *contract.CallOpts) (*big.Int, error) {
+	return i.callBigInt(ctx, opts, "maxRedeem", common.HexToAddress(owner))
+}
+
+func (i *impl) Deposit(ctx context.Context, signer signer.Signer, assets *big.Int, receiver string, opts *contract.TransactOpts) (*types.Tx, error) {
+	if err := contract.RequireAddresses(receiver); err != nil {
+		return nil, err
+	}
+
+	return i.ERC20.TransactWithSigner(ctx, signer, opts, "deposit", assets, common.HexToAddress(receiver))
+}
+
+func (i *impl) Mint(ctx context.Context, signer signer.Signer, shares *big.Int, receiver string, opts *contract.TransactOpts) (*types.Tx, error) {
+	if err := contract.RequireAddresses(receiver); err != nil {
+		return nil, err
+	}
+
+	return i.ERC20.TransactWithSigner(ctx, signer, opts, "mint", shares, common.HexToAddress(receiver))
+}
+
+func (i *impl) Withdraw(ctx context.Context, signer signer.Signer, assets *big.Int, receiver string, owner string, opts *contract.TransactOpts) (*types.Tx, error) {
+	if err := contract.RequireAddresses(receiver, owner); err != nil {
+		return nil, err
+	}
+
+	return i.ERC20.TransactWithSigner(ctx, signer, opts, "withdraw", assets, common.HexToAddress(receiver), common.HexToAddress(owner))
+}
+
+func (i *impl) Redeem(ctx context.Context, signer signer.Signer, shares *big.Int, receiver string, owner string, opts *contract.TransactOpts) (*types.Tx, error) {
+	if err := contract.RequireAddresses(receiver, owner); err != nil {
+		return nil, err
+	}
+
+	return i.ERC20.TransactWithSigner(ctx, signer, opts, "redeem", shares, common.HexToAddress(receiver), common.HexToAddress(owner))
+}
+
+// SharePriceAt evaluates convertToAssets for one whole share against the state at blockNumber.
+func (i *impl) SharePriceAt(ctx context.Context, blockNumber *big.Int) (*SharePrice, error) {
+	header, err := i.provider.HeaderByNumber(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &contract.CallOpts{BlockNumber: header.Number}
+
+	decimals, err := i.DecimalsAt(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	oneShare := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+
+	assetsPerShare, err := i.ConvertToAssetsAt(ctx, oneShare, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SharePrice{
+		BlockNumber:    header.Number.Uint64(),
+		Timestamp:      header.Time,
+		AssetsPerShare: assetsPerShare,
+	}, nil
+}
+
+// Yield compares the share price at two blocks and annualises the change.
+func (i *impl) Yield(ctx context.Context, fromBlock *big.Int, toBlock *big.Int) (*Yield, error) {
+	from, err := i.SharePriceAt(ctx, fromBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := i.SharePriceAt(ctx, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	if to.Timestamp <= from.Timestamp {
+		return nil, ErrInvalidBlockRange
+	}
+	if from.AssetsPerShare.Sign() == 0 {
+		return nil, ErrZeroSharePrice
+	}
+
+	ratio, _ := new(big.Rat).SetFrac(to.AssetsPerShare, from.AssetsPerShare).Float64()
+	elapsed := float64(to.Timestamp - from.Timestamp)
+
+	return &Yield{
+		From:         from,
+		To:           to,
+		PeriodReturn: ratio - 1,
+		APY:          math.Pow(ratio, secondsPerYear/elapsed) - 1,
+	}, nil
+}
+
+func (i *impl) callBigInt(ctx context.Context, opts *contract.CallOpts, method string, params ...any) (*big.Int, error) {
+	result, err := i.ERC20.CallWithOpts(ctx, opts, method, params...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Index(0).AsBigInt()
+}
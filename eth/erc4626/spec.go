@@ -0,0 +1,61 @@
+package erc4626
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/erc20"
+	"github.com/dtome123/go-bcwe3/eth/signer"
+	"github.com/dtome123/go-bcwe3/eth/types"
+)
+
+type ERC4626 interface {
+	erc20.ERC20
+	Asset(ctx context.Context) (string, error)
+	AssetAt(ctx context.Context, opts *contract.CallOpts) (string, error)
+	TotalAssets(ctx context.Context) (*big.Int, error)
+	TotalAssetsAt(ctx context.Context, opts *contract.CallOpts) (*big.Int, error)
+	ConvertToShares(ctx context.Context, assets *big.Int) (*big.Int, error)
+	ConvertToSharesAt(ctx context.Context, assets *big.Int, opts *contract.CallOpts) (*big.Int, error)
+	ConvertToAssets(ctx context.Context, shares *big.Int) (*big.Int, error)
+	ConvertToAssetsAt(ctx context.Context, shares *big.Int, opts *contract.CallOpts) (*big.Int, error)
+	PreviewDeposit(ctx context.Context, assets *big.Int) (*big.Int, error)
+	PreviewDepositAt(ctx context.Context, assets *big.Int, opts *contract.CallOpts) (*big.Int, error)
+	PreviewMint(ctx context.Context, shares *big.Int) (*big.Int, error)
+	PreviewMintAt(ctx context.Context, shares *big.Int, opts *contract.CallOpts) (*big.Int, error)
+	PreviewWithdraw(ctx context.Context, assets *big.Int) (*big.Int, error)
+	PreviewWithdrawAt(ctx context.Context, assets *big.Int, opts *contract.CallOpts) (*big.Int, error)
+	PreviewRedeem(ctx context.Context, shares *big.Int) (*big.Int, error)
+	PreviewRedeemAt(ctx context.Context, shares *big.Int, opts *contract.CallOpts) (*big.Int, error)
+	MaxDeposit(ctx context.Context, receiver string) (*big.Int, error)
+	MaxDepositAt(ctx context.Context, receiver string, opts *contract.CallOpts) (*big.Int, error)
+	MaxMint(ctx context.Context, receiver string) (*big.Int, error)
+	MaxMintAt(ctx context.Context, receiver string, opts *contract.CallOpts) (*big.Int, error)
+	MaxWithdraw(ctx context.Context, owner string) (*big.Int, error)
+	MaxWithdrawAt(ctx context.Context, owner string, opts *contract.CallOpts) (*big.Int, error)
+	MaxRedeem(ctx context.Context, owner string) (*big.Int, error)
+	MaxRedeemAt(ctx context.Context, owner string, opts *contract.CallOpts) (*big.Int, error)
+	Deposit(ctx context.Context, signer signer.Signer, assets *big.Int, receiver string, opts *contract.TransactOpts) (*types.Tx, error)
+	Mint(ctx context.Context, signer signer.Signer, shares *big.Int, receiver string, opts *contract.TransactOpts) (*types.Tx, error)
+	Withdraw(ctx context.Context, signer signer.Signer, assets *big.Int, receiver string, owner string, opts *contract.TransactOpts) (*types.Tx, error)
+	Redeem(ctx context.Context, signer signer.Signer, shares *big.Int, receiver string, owner string, opts *contract.TransactOpts) (*types.Tx, error)
+	SharePriceAt(ctx context.Context, blockNumber *big.Int) (*SharePrice, error)
+	Yield(ctx context.Context, fromBlock *big.Int, toBlock *big.Int) (*Yield, error)
+}
+
+// SharePrice is the amount of assets redeemable for one whole share (10^decimals share units).
+type SharePrice struct {
+	BlockNumber    uint64   `json:"block_number"`
+	Timestamp      uint64   `json:"timestamp"`
+	AssetsPerShare *big.Int `json:"assets_per_share"`
+}
+
+type Yield struct {
+	From *SharePrice `json:"from"`
+	To   *SharePrice `json:"to"`
+	// PeriodReturn is the relative share price change between the two blocks.
+	PeriodReturn float64 `json:"period_return"`
+	// APY annualises PeriodReturn with compounding over the elapsed time.
+	APY float64 `json:"apy"`
+}
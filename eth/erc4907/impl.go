@@ -0,0 +1,130 @@
+package erc4907
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/dtome123/go-bcwe3/eth/constants"
+	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/erc165"
+	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/types"
+)
+
+var (
+	ErrRentalsUnsupported = errors.New("contract does not support ERC-4907 rentals")
+)
+
+type impl struct {
+	provider provider.Provider
+	address  string
+	contract contract.Contract
+	erc165   erc165.ERC165
+}
+
+func New(address string, provider provider.Provider) (ERC4907, error) {
+
+	erc165, err := erc165.New(address, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := contract.NewContract(provider, address, constants.ERC4907ABI)
+	if err != nil {
+		return nil, err
+	}
+
+	return &impl{
+		provider: provider,
+		address:  address,
+		contract: contract,
+		erc165:   erc165,
+	}, nil
+}
+
+func (i *impl) SupportsRentals(ctx context.Context) (bool, error) {
+	return i.erc165.SupportInterface(ctx, i.address, constants.ERC4907InterfaceID)
+}
+
+// UserOf returns the current user of tokenId, or the zero address once the rental expired.
+func (i *impl) UserOf(ctx context.Context, tokenId *big.Int) (string, error) {
+	return i.UserOfAt(ctx, tokenId, nil)
+}
+
+// UserOfAt is UserOf at the block selected by opts.
+func (i *impl) UserOfAt(ctx context.Context, tokenId *big.Int, opts *contract.CallOpts) (string, error) {
+	if err := i.requireSupport(ctx); err != nil {
+		return "", err
+	}
+
+	result, err := i.contract.CallWithOpts(ctx, opts, "userOf", tokenId)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := result.Index(0).AsAddress()
+	if err != nil {
+		return "", err
+	}
+
+	return user.Hex(), nil
+}
+
+// UserExpires returns the unix timestamp at which the rental of tokenId ends.
+func (i *impl) UserExpires(ctx context.Context, tokenId *big.Int) (uint64, error) {
+	return i.UserExpiresAt(ctx, tokenId, nil)
+}
+
+// UserExpiresAt is UserExpires at the block selected by opts.
+func (i *impl) UserExpiresAt(ctx context.Context, tokenId *big.Int, opts *contract.CallOpts) (uint64, error) {
+	if err := i.requireSupport(ctx); err != nil {
+		return 0, err
+	}
+
+	result, err := i.contract.CallWithOpts(ctx, opts, "userExpires", tokenId)
+	if err != nil {
+		return 0, err
+	}
+
+	expires, err := result.Index(0).AsBigInt()
+	if err != nil {
+		return 0, err
+	}
+
+	return expires.Uint64(), nil
+}
+
+func (i *impl) GetRental(ctx context.Context, tokenId *big.Int) (*types.NFTRental, error) {
+	return i.GetRentalAt(ctx, tokenId, nil)
+}
+
+// GetRentalAt is GetRental at the block selected by opts.
+func (i *impl) GetRentalAt(ctx context.Context, tokenId *big.Int, opts *contract.CallOpts) (*types.NFTRental, error) {
+	user, err := i.UserOfAt(ctx, tokenId, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	expires, err := i.UserExpiresAt(ctx, tokenId, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.NFTRental{
+		TokenId: tokenId.String(),
+		User:    user,
+		Expires: expires,
+	}, nil
+}
+
+func (i *impl) requireSupport(ctx context.Context) error {
+	supported, err := i.SupportsRentals(ctx)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return ErrRentalsUnsupported
+	}
+	return nil
+}
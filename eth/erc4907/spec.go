@@ -0,0 +1,19 @@
+package erc4907
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/types"
+)
+
+type ERC4907 interface {
+	SupportsRentals(ctx context.Context) (bool, error)
+	UserOf(ctx context.Context, tokenId *big.Int) (string, error)
+	UserOfAt(ctx context.Context, tokenId *big.Int, opts *contract.CallOpts) (string, error)
+	UserExpires(ctx context.Context, tokenId *big.Int) (uint64, error)
+	UserExpiresAt(ctx context.Context, tokenId *big.Int, opts *contract.CallOpts) (uint64, error)
+	GetRental(ctx context.Context, tokenId *big.Int) (*types.NFTRental, error)
+	GetRentalAt(ctx context.Context, tokenId *big.Int, opts *contract.CallOpts) (*types.NFTRental, error)
+}
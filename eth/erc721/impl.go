@@ -1,26 +1,61 @@
 package erc721
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
-	"sort"
+	"strings"
+	"sync"
 
 	"github.com/dtome123/go-bcwe3/eth/constants"
 	"github.com/dtome123/go-bcwe3/eth/contract"
 	"github.com/dtome123/go-bcwe3/eth/erc165"
+	"github.com/dtome123/go-bcwe3/eth/erc2981"
+	"github.com/dtome123/go-bcwe3/eth/erc4907"
+	"github.com/dtome123/go-bcwe3/eth/metadata"
 	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/revert"
+	"github.com/dtome123/go-bcwe3/eth/scanner"
+	"github.com/dtome123/go-bcwe3/eth/signer"
 	"github.com/dtome123/go-bcwe3/eth/types"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/sync/errgroup"
 )
 
+const (
+	inventoryParallelism = 8
+	// maxInventorySize bounds the balance GetOwnerInventory enumerates, so a
+	// hostile balanceOf cannot make it allocate or call without limit.
+	maxInventorySize = 10000
+)
+
+var (
+	ErrMetadataUnsupported   = errors.New("contract does not support ERC721Metadata")
+	ErrEnumerableUnsupported = errors.New("contract does not support ERC721Enumerable")
+	ErrTransferWouldFail     = errors.New("transfer simulation failed")
+	ErrReceiverNotSupported  = errors.New("recipient contract does not implement onERC721Received")
+	ErrInventoryTooLarge     = errors.New("owner balance exceeds the inventory limit")
+)
+
+var receiverABI, _ = abi.JSON(strings.NewReader(constants.ERC721ReceiverABI))
+
 type impl struct {
 	provider provider.Provider
+	scanner  scanner.LogScanner
 	address  string
 	contract.Contract
 	erc165.ERC165
+	erc2981.ERC2981
+	erc4907.ERC4907
+
+	// supported caches ERC-165 probe results keyed by interface id
+	supported sync.Map
 }
 
 func New(
@@ -38,11 +73,24 @@ func New(
 		return nil, err
 	}
 
+	royalties, err := erc2981.New(address, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	rentals, err := erc4907.New(address, provider)
+	if err != nil {
+		return nil, err
+	}
+
 	return &impl{
 		provider: provider,
+		scanner:  scanner.NewLogScanner(provider, nil),
 		address:  address,
 		Contract: contract,
 		ERC165:   erc165,
+		ERC2981:  royalties,
+		ERC4907:  rentals,
 	}, nil
 }
 
@@ -58,14 +106,11 @@ func (i *impl) GetOwnerTokens(ctx context.Context) ([]*types.NFTBalance, error)
 		Topics:    [][]common.Hash{{eventID}},
 	}
 
-	logs, err := i.provider.FilterLogs(ctx, query)
+	logs, err := i.scanner.FilterLogs(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	sort.Slice(logs, func(i, j int) bool {
-		return logs[i].BlockNumber < logs[j].BlockNumber
-	})
 	owners := make(map[string]string)
 
 	for _, log := range logs {
@@ -101,15 +146,16 @@ func (i *impl) GetOwnerTokens(ctx context.Context) ([]*types.NFTBalance, error)
 }
 
 func (i *impl) IsERC721(ctx context.Context, contractAddr string) (bool, error) {
-	// ERC-721 interfaceId is "0x80ac58cd"
-	interfaceIdBytes := [4]byte{0x80, 0xac, 0x58, 0xcd}
-
-	return i.SupportInterface(ctx, contractAddr, interfaceIdBytes)
+	return i.SupportInterface(ctx, contractAddr, constants.ERC721InterfaceID)
 }
 
 func (i *impl) GetBalanceOf(ctx context.Context, account string) (*big.Int, error) {
+	return i.GetBalanceOfAt(ctx, account, nil)
+}
 
-	result, err := i.Contract.Call(context.Background(), "balanceOf", common.HexToAddress(account))
+// GetBalanceOfAt is GetBalanceOf at the block selected by opts.
+func (i *impl) GetBalanceOfAt(ctx context.Context, account string, opts *contract.CallOpts) (*big.Int, error) {
+	result, err := i.Contract.CallWithOpts(ctx, opts, "balanceOf", common.HexToAddress(account))
 
 	if err != nil {
 		return nil, err
@@ -119,8 +165,26 @@ func (i *impl) GetBalanceOf(ctx context.Context, account string) (*big.Int, erro
 }
 
 func (i *impl) GetOwnerOf(ctx context.Context, tokenId *big.Int) (string, error) {
+	return i.GetOwnerOfAt(ctx, tokenId, nil)
+}
 
-	result, err := i.Contract.Call(context.Background(), "ownerOf", tokenId)
+// GetOwnerOfAt is GetOwnerOf at the block selected by opts.
+func (i *impl) GetOwnerOfAt(ctx context.Context, tokenId *big.Int, opts *contract.CallOpts) (string, error) {
+	var owner string
+	if err := i.Contract.CallIntoWithOpts(ctx, opts, &owner, "ownerOf", tokenId); err != nil {
+		return "", err
+	}
+
+	return owner, nil
+}
+
+func (i *impl) GetName(ctx context.Context) (string, error) {
+	return i.GetNameAt(ctx, nil)
+}
+
+// GetNameAt is GetName at the block selected by opts.
+func (i *impl) GetNameAt(ctx context.Context, opts *contract.CallOpts) (string, error) {
+	result, err := i.Contract.CallWithOpts(ctx, opts, "name")
 
 	if err != nil {
 		return "", err
@@ -129,9 +193,13 @@ func (i *impl) GetOwnerOf(ctx context.Context, tokenId *big.Int) (string, error)
 	return result.Index(0).AsString()
 }
 
-func (i *impl) GetName(ctx context.Context) (string, error) {
+func (i *impl) GetSymbol(ctx context.Context) (string, error) {
+	return i.GetSymbolAt(ctx, nil)
+}
 
-	result, err := i.Contract.Call(context.Background(), "name")
+// GetSymbolAt is GetSymbol at the block selected by opts.
+func (i *impl) GetSymbolAt(ctx context.Context, opts *contract.CallOpts) (string, error) {
+	result, err := i.Contract.CallWithOpts(ctx, opts, "symbol")
 
 	if err != nil {
 		return "", err
@@ -140,9 +208,25 @@ func (i *impl) GetName(ctx context.Context) (string, error) {
 	return result.Index(0).AsString()
 }
 
-func (i *impl) GetSymbol(ctx context.Context) (string, error) {
+func (i *impl) SupportsMetadata(ctx context.Context) (bool, error) {
+	return i.supports(ctx, constants.ERC721MetadataInterfaceID)
+}
 
-	result, err := i.Contract.Call(context.Background(), "symbol")
+func (i *impl) SupportsEnumerable(ctx context.Context) (bool, error) {
+	return i.supports(ctx, constants.ERC721EnumerableInterfaceID)
+}
+
+func (i *impl) GetTokenURI(ctx context.Context, tokenId *big.Int) (string, error) {
+	return i.GetTokenURIAt(ctx, tokenId, nil)
+}
+
+// GetTokenURIAt is GetTokenURI at the block selected by opts.
+func (i *impl) GetTokenURIAt(ctx context.Context, tokenId *big.Int, opts *contract.CallOpts) (string, error) {
+	if err := i.require(ctx, constants.ERC721MetadataInterfaceID, ErrMetadataUnsupported); err != nil {
+		return "", err
+	}
+
+	result, err := i.Contract.CallWithOpts(ctx, opts, "tokenURI", tokenId)
 
 	if err != nil {
 		return "", err
@@ -150,3 +234,318 @@ func (i *impl) GetSymbol(ctx context.Context) (string, error) {
 
 	return result.Index(0).AsString()
 }
+
+func (i *impl) GetTotalSupply(ctx context.Context) (*big.Int, error) {
+	return i.GetTotalSupplyAt(ctx, nil)
+}
+
+// GetTotalSupplyAt is GetTotalSupply at the block selected by opts.
+func (i *impl) GetTotalSupplyAt(ctx context.Context, opts *contract.CallOpts) (*big.Int, error) {
+	if err := i.require(ctx, constants.ERC721EnumerableInterfaceID, ErrEnumerableUnsupported); err != nil {
+		return nil, err
+	}
+
+	result, err := i.Contract.CallWithOpts(ctx, opts, "totalSupply")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Index(0).AsBigInt()
+}
+
+func (i *impl) GetTokenByIndex(ctx context.Context, index *big.Int) (*big.Int, error) {
+	return i.GetTokenByIndexAt(ctx, index, nil)
+}
+
+// GetTokenByIndexAt is GetTokenByIndex at the block selected by opts.
+func (i *impl) GetTokenByIndexAt(ctx context.Context, index *big.Int, opts *contract.CallOpts) (*big.Int, error) {
+	if err := i.require(ctx, constants.ERC721EnumerableInterfaceID, ErrEnumerableUnsupported); err != nil {
+		return nil, err
+	}
+
+	result, err := i.Contract.CallWithOpts(ctx, opts, "tokenByIndex", index)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Index(0).AsBigInt()
+}
+
+func (i *impl) GetTokenOfOwnerByIndex(ctx context.Context, owner string, index *big.Int) (*big.Int, error) {
+	return i.GetTokenOfOwnerByIndexAt(ctx, owner, index, nil)
+}
+
+// GetTokenOfOwnerByIndexAt is GetTokenOfOwnerByIndex at the block selected by opts.
+func (i *impl) GetTokenOfOwnerByIndexAt(ctx context.Context, owner string, index *big.Int, opts *contract.CallOpts) (*big.Int, error) {
+	if err := i.require(ctx, constants.ERC721EnumerableInterfaceID, ErrEnumerableUnsupported); err != nil {
+		return nil, err
+	}
+
+	result, err := i.Contract.CallWithOpts(ctx, opts, "tokenOfOwnerByIndex", common.HexToAddress(owner), index)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Index(0).AsBigInt()
+}
+
+// GetOwnerInventory lists the tokens held by owner. Enumerable collections are read
+// with tokenOfOwnerByIndex at one block; other collections fall back to replaying
+// Transfer logs.
+func (i *impl) GetOwnerInventory(ctx context.Context, owner string) ([]*types.NFTBalance, error) {
+	if err := contract.RequireAddresses(owner); err != nil {
+		return nil, err
+	}
+
+	enumerable, err := i.SupportsEnumerable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerAddress := common.HexToAddress(owner)
+
+	if !enumerable {
+		tokens, err := i.GetOwnerTokens(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		out := make([]*types.NFTBalance, 0)
+		for _, token := range tokens {
+			if common.HexToAddress(token.Owner) == ownerAddress {
+				out = append(out, token)
+			}
+		}
+		return out, nil
+	}
+
+	pinned, _, err := contract.PinBlock(ctx, i.provider, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := i.GetBalanceOfAt(ctx, owner, pinned)
+	if err != nil {
+		return nil, err
+	}
+	if !balance.IsInt64() || balance.Int64() > maxInventorySize {
+		return nil, fmt.Errorf("%w: %s", ErrInventoryTooLarge, balance)
+	}
+
+	out := make([]*types.NFTBalance, balance.Int64())
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(inventoryParallelism)
+
+	for idx := range out {
+		g.Go(func() error {
+			tokenId, err := i.GetTokenOfOwnerByIndexAt(gctx, owner, big.NewInt(int64(idx)), pinned)
+			if err != nil {
+				return err
+			}
+
+			out[idx] = &types.NFTBalance{
+				Token: types.NFT{
+					ContractAddress: i.address,
+					TokenId:         tokenId.String(),
+					Standard:        types.ERC721,
+				},
+				Owner:   ownerAddress.Hex(),
+				Balance: big.NewInt(1),
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetTokenMetadata reads tokenURI and resolves it through resolver.
+func (i *impl) GetTokenMetadata(ctx context.Context, tokenId *big.Int, resolver metadata.Resolver) (*metadata.Metadata, error) {
+	uri, err := i.GetTokenURI(ctx, tokenId)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolver.Resolve(ctx, uri)
+}
+
+func (i *impl) supports(ctx context.Context, interfaceId [4]byte) (bool, error) {
+	if cached, ok := i.supported.Load(interfaceId); ok {
+		return cached.(bool), nil
+	}
+
+	ok, err := i.SupportInterface(ctx, i.address, interfaceId)
+	if err != nil {
+		return false, err
+	}
+
+	i.supported.Store(interfaceId, ok)
+
+	return ok, nil
+}
+
+func (i *impl) require(ctx context.Context, interfaceId [4]byte, unsupported error) error {
+	ok, err := i.supports(ctx, interfaceId)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return unsupported
+	}
+	return nil
+}
+
+func (i *impl) GetApproved(ctx context.Context, tokenId *big.Int) (string, error) {
+	return i.GetApprovedAt(ctx, tokenId, nil)
+}
+
+// GetApprovedAt is GetApproved at the block selected by opts.
+func (i *impl) GetApprovedAt(ctx context.Context, tokenId *big.Int, opts *contract.CallOpts) (string, error) {
+	result, err := i.Contract.CallWithOpts(ctx, opts, "getApproved", tokenId)
+
+	if err != nil {
+		return "", err
+	}
+
+	approved, err := result.Index(0).AsAddress()
+	if err != nil {
+		return "", err
+	}
+
+	return approved.Hex(), nil
+}
+
+func (i *impl) IsApprovedForAll(ctx context.Context, owner string, operator string) (bool, error) {
+	return i.IsApprovedForAllAt(ctx, owner, operator, nil)
+}
+
+// IsApprovedForAllAt is IsApprovedForAll at the block selected by opts.
+func (i *impl) IsApprovedForAllAt(ctx context.Context, owner string, operator string, opts *contract.CallOpts) (bool, error) {
+	result, err := i.Contract.CallWithOpts(ctx, opts, "isApprovedForAll", common.HexToAddress(owner), common.HexToAddress(operator))
+
+	if err != nil {
+		return false, err
+	}
+
+	return result.Index(0).AsBool()
+}
+
+// CheckTransfer simulates safeTransferFrom as operator with eth_call and, when the
+// recipient is a contract, calls its onERC721Received hook from the collection address
+// to make sure it accepts the token. Run it before TransferFrom, which skips the hook.
+func (i *impl) CheckTransfer(ctx context.Context, operator string, from string, to string, tokenId *big.Int, data []byte) error {
+	if err := contract.RequireAddresses(operator, from, to); err != nil {
+		return err
+	}
+	if data == nil {
+		data = []byte{}
+	}
+
+	method, err := i.safeTransferMethod()
+	if err != nil {
+		return err
+	}
+
+	input, err := i.Contract.ABI().Pack(method, common.HexToAddress(from), common.HexToAddress(to), tokenId, data)
+	if err != nil {
+		return err
+	}
+
+	collection := common.HexToAddress(i.address)
+	if _, err := i.provider.CallContract(ctx, ethereum.CallMsg{
+		From: common.HexToAddress(operator),
+		To:   &collection,
+		Data: input,
+	}, nil); err != nil {
+		return fmt.Errorf("%w: %w", ErrTransferWouldFail, revert.Wrap(err, i.Contract.ABI()))
+	}
+
+	code, err := i.provider.CodeAt(ctx, to, nil)
+	if err != nil {
+		return err
+	}
+	if len(code) == 0 {
+		return nil
+	}
+
+	hookInput, err := receiverABI.Pack("onERC721Received", common.HexToAddress(operator), common.HexToAddress(from), tokenId, data)
+	if err != nil {
+		return err
+	}
+
+	recipient := common.HexToAddress(to)
+	output, err := i.provider.CallContract(ctx, ethereum.CallMsg{
+		From: collection,
+		To:   &recipient,
+		Data: hookInput,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrReceiverNotSupported, err)
+	}
+
+	if len(output) < 4 || !bytes.Equal(output[:4], constants.ERC721ReceivedSelector[:]) {
+		return ErrReceiverNotSupported
+	}
+
+	return nil
+}
+
+func (i *impl) TransferFrom(ctx context.Context, signer signer.Signer, from string, to string, tokenId *big.Int, opts *contract.TransactOpts) (*types.Tx, error) {
+	if err := contract.RequireAddresses(from, to); err != nil {
+		return nil, err
+	}
+
+	return i.Contract.TransactWithSigner(ctx, signer, opts, "transferFrom", common.HexToAddress(from), common.HexToAddress(to), tokenId)
+}
+
+func (i *impl) SafeTransferFrom(ctx context.Context, signer signer.Signer, from string, to string, tokenId *big.Int, data []byte, opts *contract.TransactOpts) (*types.Tx, error) {
+	if err := contract.RequireAddresses(from, to); err != nil {
+		return nil, err
+	}
+	if data == nil {
+		data = []byte{}
+	}
+
+	method, err := i.safeTransferMethod()
+	if err != nil {
+		return nil, err
+	}
+
+	return i.Contract.TransactWithSigner(ctx, signer, opts, method, common.HexToAddress(from), common.HexToAddress(to), tokenId, data)
+}
+
+func (i *impl) Approve(ctx context.Context, signer signer.Signer, to string, tokenId *big.Int, opts *contract.TransactOpts) (*types.Tx, error) {
+	if err := contract.RequireAddresses(to); err != nil {
+		return nil, err
+	}
+
+	return i.Contract.TransactWithSigner(ctx, signer, opts, "approve", common.HexToAddress(to), tokenId)
+}
+
+func (i *impl) SetApprovalForAll(ctx context.Context, signer signer.Signer, operator string, approved bool, opts *contract.TransactOpts) (*types.Tx, error) {
+	if err := contract.RequireAddresses(operator); err != nil {
+		return nil, err
+	}
+
+	return i.Contract.TransactWithSigner(ctx, signer, opts, "setApprovalForAll", common.HexToAddress(operator), approved)
+}
+
+// safeTransferMethod returns the ABI name go-ethereum assigned to the overloaded
+// safeTransferFrom(address,address,uint256,bytes).
+func (i *impl) safeTransferMethod() (string, error) {
+	for name, method := range i.Contract.ABI().Methods {
+		if method.RawName == "safeTransferFrom" && len(method.Inputs) == 4 {
+			return name, nil
+		}
+	}
+
+	return "", errors.New("safeTransferFrom with data not found in ABI")
+}
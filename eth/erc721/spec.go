@@ -5,15 +5,50 @@ import (
 	"math/big"
 
 	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/erc2981"
+	"github.com/dtome123/go-bcwe3/eth/erc4907"
+	"github.com/dtome123/go-bcwe3/eth/metadata"
+	"github.com/dtome123/go-bcwe3/eth/signer"
 	"github.com/dtome123/go-bcwe3/eth/types"
 )
 
 type ERC721 interface {
 	contract.Contract
+	erc2981.ERC2981
+	erc4907.ERC4907
 	GetOwnerTokens(ctx context.Context) ([]*types.NFTBalance, error)
 	IsERC721(ctx context.Context, contractAddr string) (bool, error)
 	GetBalanceOf(ctx context.Context, account string) (*big.Int, error)
+	GetBalanceOfAt(ctx context.Context, account string, opts *contract.CallOpts) (*big.Int, error)
 	GetOwnerOf(ctx context.Context, tokenId *big.Int) (string, error)
+	GetOwnerOfAt(ctx context.Context, tokenId *big.Int, opts *contract.CallOpts) (string, error)
 	GetName(ctx context.Context) (string, error)
+	GetNameAt(ctx context.Context, opts *contract.CallOpts) (string, error)
 	GetSymbol(ctx context.Context) (string, error)
+	GetSymbolAt(ctx context.Context, opts *contract.CallOpts) (string, error)
+
+	// ERC721Metadata / ERC721Enumerable
+	SupportsMetadata(ctx context.Context) (bool, error)
+	SupportsEnumerable(ctx context.Context) (bool, error)
+	GetTokenURI(ctx context.Context, tokenId *big.Int) (string, error)
+	GetTokenURIAt(ctx context.Context, tokenId *big.Int, opts *contract.CallOpts) (string, error)
+	GetTotalSupply(ctx context.Context) (*big.Int, error)
+	GetTotalSupplyAt(ctx context.Context, opts *contract.CallOpts) (*big.Int, error)
+	GetTokenByIndex(ctx context.Context, index *big.Int) (*big.Int, error)
+	GetTokenByIndexAt(ctx context.Context, index *big.Int, opts *contract.CallOpts) (*big.Int, error)
+	GetTokenOfOwnerByIndex(ctx context.Context, owner string, index *big.Int) (*big.Int, error)
+	GetTokenOfOwnerByIndexAt(ctx context.Context, owner string, index *big.Int, opts *contract.CallOpts) (*big.Int, error)
+	GetOwnerInventory(ctx context.Context, owner string) ([]*types.NFTBalance, error)
+	GetTokenMetadata(ctx context.Context, tokenId *big.Int, resolver metadata.Resolver) (*metadata.Metadata, error)
+
+	// approvals and transfers
+	GetApproved(ctx context.Context, tokenId *big.Int) (string, error)
+	GetApprovedAt(ctx context.Context, tokenId *big.Int, opts *contract.CallOpts) (string, error)
+	IsApprovedForAll(ctx context.Context, owner string, operator string) (bool, error)
+	IsApprovedForAllAt(ctx context.Context, owner string, operator string, opts *contract.CallOpts) (bool, error)
+	CheckTransfer(ctx context.Context, operator string, from string, to string, tokenId *big.Int, data []byte) error
+	TransferFrom(ctx context.Context, signer signer.Signer, from string, to string, tokenId *big.Int, opts *contract.TransactOpts) (*types.Tx, error)
+	SafeTransferFrom(ctx context.Context, signer signer.Signer, from string, to string, tokenId *big.Int, data []byte, opts *contract.TransactOpts) (*types.Tx, error)
+	Approve(ctx context.Context, signer signer.Signer, to string, tokenId *big.Int, opts *contract.TransactOpts) (*types.Tx, error)
+	SetApprovalForAll(ctx context.Context, signer signer.Signer, operator string, approved bool, opts *contract.TransactOpts) (*types.Tx, error)
 }
@@ -1,11 +1,18 @@
 package eth
 
 import (
+	"context"
+
 	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/deployer"
+	"github.com/dtome123/go-bcwe3/eth/detector"
 	"github.com/dtome123/go-bcwe3/eth/erc1155"
 	"github.com/dtome123/go-bcwe3/eth/erc20"
+	"github.com/dtome123/go-bcwe3/eth/erc4626"
 	"github.com/dtome123/go-bcwe3/eth/erc721"
 	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/signer"
+	"github.com/dtome123/go-bcwe3/eth/verifier"
 )
 
 type impl struct {
@@ -40,6 +47,31 @@ func (eth *impl) NewERC20(address string) (erc20.ERC20, error) {
 	return erc20.New(address, eth.provider)
 }
 
+func (eth *impl) NewERC4626(address string) (erc4626.ERC4626, error) {
+	return erc4626.New(address, eth.provider)
+}
+
 func (eth *impl) NewContract(address string, abiData string) (contract.Contract, error) {
 	return contract.NewContract(eth.provider, address, abiData)
 }
+
+func (eth *impl) DetectStandard(ctx context.Context, address string) (*detector.Detection, error) {
+	return detector.NewDetector(eth.provider).DetectStandard(ctx, address)
+}
+
+func (eth *impl) VerifySignature(ctx context.Context, req *verifier.VerifyRequest) error {
+	return verifier.NewVerifier(eth.provider).Verify(ctx, req)
+}
+
+func (eth *impl) DeployContract(ctx context.Context, signer signer.Signer, abiData string, bytecode string, args ...any) (string, contract.Contract, error) {
+	deployment, err := deployer.NewDeployer(eth.provider, nil).Deploy(ctx, signer, nil, abiData, bytecode, args...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return deployment.Address, deployment.Contract, nil
+}
+
+func (eth *impl) NewDeployer(config *deployer.Config) deployer.Deployer {
+	return deployer.NewDeployer(eth.provider, config)
+}
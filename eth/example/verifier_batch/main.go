@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/dtome123/go-bcwe3/eth/signer"
+	"github.com/dtome123/go-bcwe3/eth/verifier"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Compares verifying airdrop claims one by one with verifier.Verify against
+// verifier.VerifyBatch.
+//
+//	go run ./eth/example/verifier_batch -n 5000 -workers 8
+func main() {
+	n := flag.Int("n", 2000, "number of signed claims")
+	workers := flag.Int("workers", 0, "batch workers (0 = NumCPU)")
+	flag.Parse()
+
+	reqs := buildClaims(*n)
+
+	start := time.Now()
+	for _, req := range reqs {
+		if err := verifier.Verify(req); err != nil {
+			log.Fatal("verify: ", err)
+		}
+	}
+	sequential := time.Since(start)
+
+	start = time.Now()
+	results := verifier.VerifyBatch(context.Background(), reqs, &verifier.BatchOptions{Workers: *workers})
+	batch := time.Since(start)
+
+	for i, res := range results {
+		if res.Err != nil {
+			log.Fatalf("batch item %d: %v", i, res.Err)
+		}
+	}
+
+	log.Printf("sequential: %v (%v/op)", sequential, sequential/time.Duration(*n))
+	log.Printf("batch:      %v (%v/op)", batch, batch/time.Duration(*n))
+	log.Printf("speedup:    %.1fx", float64(sequential)/float64(batch))
+}
+
+func buildClaims(n int) []*verifier.VerifyRequest {
+	types := apitypes.Types{
+		"Claim": []apitypes.Type{
+			{Name: "account", Type: "address"},
+			{Name: "amount", Type: "uint256"},
+			{Name: "deadline", Type: "uint256"},
+		},
+	}
+	domain := apitypes.TypedDataDomain{
+		Name:              "Airdrop",
+		Version:           "1",
+		ChainId:           math.NewHexOrDecimal256(1),
+		VerifyingContract: "0x0000000000000000000000000000000000000001",
+	}
+
+	keys := make([]*ecdsa.PrivateKey, 16)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+	}
+
+	reqs := make([]*verifier.VerifyRequest, n)
+	for i := range reqs {
+		s := signer.NewKeySigner(keys[i%len(keys)])
+
+		typedData := &apitypes.TypedData{
+			Types:       types,
+			PrimaryType: "Claim",
+			Domain:      domain,
+			Message: apitypes.TypedDataMessage{
+				"account":  s.Address(),
+				"amount":   big.NewInt(int64(i + 1)).String(),
+				"deadline": fmt.Sprint(1_900_000_000),
+			},
+		}
+
+		sig, err := signer.SignTypedData(s, *typedData)
+		if err != nil {
+			log.Fatal("sign: ", err)
+		}
+
+		reqs[i] = &verifier.VerifyRequest{
+			SignatureType: verifier.SignatureTypedData,
+			Payload:       typedData,
+			Signature:     hexutil.Encode(sig),
+			ExpectedAddr:  s.Address(),
+		}
+	}
+
+	return reqs
+}
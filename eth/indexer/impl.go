@@ -0,0 +1,267 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/erc20"
+	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/scanner"
+	"github.com/dtome123/go-bcwe3/eth/types"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultChunkSize     = 2000
+	reconcileParallelism = 8
+)
+
+var (
+	transferEventID = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	approvalEventID = crypto.Keccak256Hash([]byte("Approval(address,address,uint256)"))
+
+	ErrInvalidRange       = errors.New("from block is after to block")
+	ErrCheckpointMismatch = errors.New("checkpoint belongs to a different token")
+)
+
+type impl struct {
+	provider provider.Provider
+	scanner  scanner.LogScanner
+	token    erc20.ERC20
+}
+
+func NewERC20Indexer(token erc20.ERC20, provider provider.Provider) ERC20Indexer {
+	return &impl{
+		provider: provider,
+		scanner:  scanner.NewLogScanner(provider, &scanner.Config{Parallelism: 1}),
+		token:    token,
+	}
+}
+
+func (i *impl) Index(ctx context.Context, opts *Options) (*Result, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	tokenAddress := common.HexToAddress(i.token.Address())
+
+	chunkSize := opts.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = defaultChunkSize
+	}
+
+	toBlock := opts.ToBlock
+	if toBlock == 0 {
+		latest, err := i.provider.BlockNumber(ctx)
+		if err != nil {
+			return nil, err
+		}
+		toBlock = latest
+	}
+
+	checkpoint := &Checkpoint{
+		Token:     tokenAddress.Hex(),
+		NextBlock: opts.FromBlock,
+		Balances:  make(map[string]*big.Int),
+	}
+	if opts.Checkpoint != nil {
+		if common.HexToAddress(opts.Checkpoint.Token) != tokenAddress {
+			return nil, ErrCheckpointMismatch
+		}
+		checkpoint.NextBlock = opts.Checkpoint.NextBlock
+		for holder, balance := range opts.Checkpoint.Balances {
+			checkpoint.Balances[holder] = new(big.Int).Set(balance)
+		}
+	}
+
+	if checkpoint.NextBlock > toBlock+1 {
+		return nil, ErrInvalidRange
+	}
+
+	result := &Result{
+		Token:     tokenAddress.Hex(),
+		FromBlock: checkpoint.NextBlock,
+		ToBlock:   toBlock,
+	}
+
+	topics := []common.Hash{transferEventID}
+	if opts.IncludeApprovals {
+		topics = append(topics, approvalEventID)
+	}
+
+	for from := checkpoint.NextBlock; from <= toBlock; from += chunkSize {
+		to := min(from+chunkSize-1, toBlock)
+
+		logs, err := i.scanner.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{tokenAddress},
+			Topics:    [][]common.Hash{topics},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, log := range logs {
+			if log.Removed || len(log.Topics) != 3 {
+				continue
+			}
+
+			switch log.Topics[0] {
+			case transferEventID:
+				transfer := decodeTransfer(log)
+				applyTransfer(checkpoint.Balances, transfer)
+				result.Transfers = append(result.Transfers, transfer)
+			case approvalEventID:
+				result.Approvals = append(result.Approvals, decodeApproval(log))
+			}
+		}
+
+		checkpoint.NextBlock = to + 1
+
+		if opts.OnCheckpoint != nil {
+			if err := opts.OnCheckpoint(ctx, checkpoint); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result.Balances = holderBalances(checkpoint.Balances)
+	result.Checkpoint = checkpoint
+
+	return result, nil
+}
+
+// Reconcile reads balanceOf at the block the balances were indexed up to, so
+// transfers made after it are not mistaken for fee-on-transfer or rebasing.
+func (i *impl) Reconcile(ctx context.Context, balances []*HolderBalance, toBlock uint64) (*Reconciliation, error) {
+	onChain := make([]*big.Int, len(balances))
+
+	opts := &contract.CallOpts{}
+	if toBlock > 0 {
+		opts.BlockNumber = new(big.Int).SetUint64(toBlock)
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(reconcileParallelism)
+
+	for idx, holder := range balances {
+		g.Go(func() error {
+			balance, err := i.token.BalanceOfAt(gctx, holder.Holder, opts)
+			if err != nil {
+				return err
+			}
+			onChain[idx] = balance
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	reconciliation := &Reconciliation{
+		Checked:  len(balances),
+		Behavior: BehaviorStandard,
+	}
+
+	short := 0
+	for idx, holder := range balances {
+		if onChain[idx].Cmp(holder.Balance) == 0 {
+			continue
+		}
+
+		variance := new(big.Int).Sub(onChain[idx], holder.Balance)
+		if variance.Sign() < 0 {
+			short++
+		}
+
+		reconciliation.Mismatches = append(reconciliation.Mismatches, &BalanceMismatch{
+			Holder:   holder.Holder,
+			Indexed:  holder.Balance,
+			OnChain:  onChain[idx],
+			Variance: variance,
+		})
+	}
+
+	switch {
+	case len(reconciliation.Mismatches) == 0:
+	case short == len(reconciliation.Mismatches):
+		reconciliation.Behavior = BehaviorFeeOnTransfer
+	default:
+		reconciliation.Behavior = BehaviorRebasing
+	}
+
+	return reconciliation, nil
+}
+
+func decodeTransfer(log types.Log) *Transfer {
+	return &Transfer{
+		From:        common.BytesToAddress(log.Topics[1].Bytes()).Hex(),
+		To:          common.BytesToAddress(log.Topics[2].Bytes()).Hex(),
+		Value:       new(big.Int).SetBytes(log.Data),
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash,
+		LogIndex:    log.Index,
+	}
+}
+
+func decodeApproval(log types.Log) *Approval {
+	return &Approval{
+		Owner:       common.BytesToAddress(log.Topics[1].Bytes()).Hex(),
+		Spender:     common.BytesToAddress(log.Topics[2].Bytes()).Hex(),
+		Value:       new(big.Int).SetBytes(log.Data),
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash,
+		LogIndex:    log.Index,
+	}
+}
+
+// applyTransfer moves value between holders; the zero address is the mint/burn sink and is not tracked.
+func applyTransfer(balances map[string]*big.Int, transfer *Transfer) {
+	zero := common.Address{}.Hex()
+
+	if transfer.From != zero {
+		balance, ok := balances[transfer.From]
+		if !ok {
+			balance = new(big.Int)
+			balances[transfer.From] = balance
+		}
+		balance.Sub(balance, transfer.Value)
+	}
+
+	if transfer.To != zero {
+		balance, ok := balances[transfer.To]
+		if !ok {
+			balance = new(big.Int)
+			balances[transfer.To] = balance
+		}
+		balance.Add(balance, transfer.Value)
+	}
+}
+
+func holderBalances(balances map[string]*big.Int) []*HolderBalance {
+	out := make([]*HolderBalance, 0, len(balances))
+	for holder, balance := range balances {
+		if balance.Sign() == 0 {
+			continue
+		}
+		out = append(out, &HolderBalance{
+			Holder:  holder,
+			Balance: new(big.Int).Set(balance),
+		})
+	}
+
+	sort.Slice(out, func(a, b int) bool {
+		return out[a].Holder < out[b].Holder
+	})
+
+	return out
+}
@@ -0,0 +1,93 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+)
+
+type ERC20Indexer interface {
+	// Index scans Transfer (and optionally Approval) events of the token and folds
+	// them into per-holder balances.
+	Index(ctx context.Context, opts *Options) (*Result, error)
+	// Reconcile compares indexed balances with balanceOf at toBlock, normally
+	// Result.ToBlock, and classifies any drift. A toBlock of 0 reads the latest block.
+	Reconcile(ctx context.Context, balances []*HolderBalance, toBlock uint64) (*Reconciliation, error)
+}
+
+type Options struct {
+	FromBlock uint64
+	// ToBlock is inclusive, 0 means the latest block.
+	ToBlock   uint64
+	ChunkSize uint64
+	// IncludeApprovals also collects Approval events into Result.Approvals.
+	IncludeApprovals bool
+	// Checkpoint resumes a previous run; FromBlock is ignored when set.
+	Checkpoint *Checkpoint
+	// OnCheckpoint is invoked after every processed chunk so progress can be persisted.
+	OnCheckpoint func(ctx context.Context, checkpoint *Checkpoint) error
+}
+
+// Checkpoint is the resumable state of an indexing run.
+type Checkpoint struct {
+	Token     string              `json:"token"`
+	NextBlock uint64              `json:"next_block"`
+	Balances  map[string]*big.Int `json:"balances"`
+}
+
+type Transfer struct {
+	From        string   `json:"from"`
+	To          string   `json:"to"`
+	Value       *big.Int `json:"value"`
+	BlockNumber uint64   `json:"block_number"`
+	TxHash      string   `json:"transaction_hash"`
+	LogIndex    uint     `json:"log_index"`
+}
+
+type Approval struct {
+	Owner       string   `json:"owner"`
+	Spender     string   `json:"spender"`
+	Value       *big.Int `json:"value"`
+	BlockNumber uint64   `json:"block_number"`
+	TxHash      string   `json:"transaction_hash"`
+	LogIndex    uint     `json:"log_index"`
+}
+
+type HolderBalance struct {
+	Holder  string   `json:"holder"`
+	Balance *big.Int `json:"balance"`
+}
+
+// Result holds the events found in this run and the balances at ToBlock.
+// When resuming from a checkpoint, Transfers and Approvals only contain new events.
+type Result struct {
+	Token      string           `json:"token"`
+	FromBlock  uint64           `json:"from_block"`
+	ToBlock    uint64           `json:"to_block"`
+	Transfers  []*Transfer      `json:"transfers"`
+	Approvals  []*Approval      `json:"approvals,omitempty"`
+	Balances   []*HolderBalance `json:"balances"`
+	Checkpoint *Checkpoint      `json:"checkpoint"`
+}
+
+type TokenBehavior int
+
+const (
+	BehaviorStandard TokenBehavior = iota
+	// BehaviorFeeOnTransfer means holders own less than their Transfer events imply.
+	BehaviorFeeOnTransfer
+	// BehaviorRebasing means balances move without Transfer events.
+	BehaviorRebasing
+)
+
+type BalanceMismatch struct {
+	Holder   string   `json:"holder"`
+	Indexed  *big.Int `json:"indexed"`
+	OnChain  *big.Int `json:"on_chain"`
+	Variance *big.Int `json:"variance"`
+}
+
+type Reconciliation struct {
+	Checked    int                `json:"checked"`
+	Mismatches []*BalanceMismatch `json:"mismatches"`
+	Behavior   TokenBehavior      `json:"behavior"`
+}
@@ -0,0 +1,173 @@
+package metadata
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	defaultIPFSGateway    = "https://ipfs.io/ipfs/"
+	defaultArweaveGateway = "https://arweave.net/"
+	maxDocumentSize       = 10 << 20
+)
+
+var (
+	ErrUnsupportedScheme = errors.New("unsupported token URI scheme")
+	ErrInvalidDataURI    = errors.New("invalid data URI")
+	ErrEmptyURI          = errors.New("token URI is empty")
+)
+
+type resolver struct {
+	fetcher        Fetcher
+	ipfsGateway    string
+	arweaveGateway string
+}
+
+// NewResolver creates a Resolver; a nil fetcher uses http.DefaultClient.
+func NewResolver(fetcher Fetcher, config *Config) Resolver {
+	if fetcher == nil {
+		fetcher = NewHTTPFetcher(nil)
+	}
+
+	r := &resolver{
+		fetcher:        fetcher,
+		ipfsGateway:    defaultIPFSGateway,
+		arweaveGateway: defaultArweaveGateway,
+	}
+
+	if config != nil {
+		if config.IPFSGateway != "" {
+			r.ipfsGateway = withTrailingSlash(config.IPFSGateway)
+		}
+		if config.ArweaveGateway != "" {
+			r.arweaveGateway = withTrailingSlash(config.ArweaveGateway)
+		}
+	}
+
+	return r
+}
+
+func (r *resolver) ResolveURL(uri string) (string, error) {
+	uri = strings.TrimSpace(uri)
+	if uri == "" {
+		return "", ErrEmptyURI
+	}
+
+	lower := strings.ToLower(uri)
+	switch {
+	case strings.HasPrefix(lower, "ipfs://"):
+		path := uri[len("ipfs://"):]
+		path = strings.TrimPrefix(path, "ipfs/")
+		return r.ipfsGateway + path, nil
+	case strings.HasPrefix(lower, "ar://"):
+		return r.arweaveGateway + uri[len("ar://"):], nil
+	case strings.HasPrefix(lower, "http://"), strings.HasPrefix(lower, "https://"), strings.HasPrefix(lower, "data:"):
+		return uri, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedScheme, uri)
+	}
+}
+
+func (r *resolver) Resolve(ctx context.Context, uri string) (*Metadata, error) {
+	resolved, err := r.ResolveURL(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var document []byte
+	if strings.HasPrefix(strings.ToLower(resolved), "data:") {
+		document, err = decodeDataURI(resolved)
+	} else {
+		document, err = r.fetcher.Fetch(ctx, resolved)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata Metadata
+	if err := json.Unmarshal(document, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata: %v", err)
+	}
+	metadata.Raw = document
+
+	return &metadata, nil
+}
+
+// decodeDataURI supports both base64 and percent-encoded RFC 2397 payloads.
+func decodeDataURI(uri string) ([]byte, error) {
+	header, payload, ok := strings.Cut(uri[len("data:"):], ",")
+	if !ok {
+		return nil, ErrInvalidDataURI
+	}
+
+	if strings.HasSuffix(strings.ToLower(header), ";base64") {
+		data, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			data, err = base64.RawStdEncoding.DecodeString(payload)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidDataURI, err)
+		}
+		return data, nil
+	}
+
+	data, err := url.PathUnescape(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDataURI, err)
+	}
+
+	return []byte(data), nil
+}
+
+func withTrailingSlash(s string) string {
+	if strings.HasSuffix(s, "/") {
+		return s
+	}
+	return s + "/"
+}
+
+type httpFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPFetcher creates a Fetcher using the given client, or http.DefaultClient when nil.
+func NewHTTPFetcher(client *http.Client) Fetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &httpFetcher{client: client}
+}
+
+func (f *httpFetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: status %d", url, resp.StatusCode)
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, io.LimitReader(resp.Body, maxDocumentSize)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,48 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Fetcher retrieves the raw document behind an http(s) URL. Implementations can
+// add caching, rate limiting or authenticated gateways.
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+// Resolver turns a token URI (ipfs://, ar://, data: or http(s)://) into metadata.
+type Resolver interface {
+	// ResolveURL rewrites ipfs:// and ar:// URIs to their gateway URL.
+	ResolveURL(uri string) (string, error)
+	Resolve(ctx context.Context, uri string) (*Metadata, error)
+}
+
+type Config struct {
+	// IPFSGateway defaults to https://ipfs.io/ipfs/.
+	IPFSGateway string
+	// ArweaveGateway defaults to https://arweave.net/.
+	ArweaveGateway string
+}
+
+// Metadata follows the ERC-721 / ERC-1155 metadata JSON schema with the
+// commonly used OpenSea extensions.
+type Metadata struct {
+	Name            string          `json:"name"`
+	Description     string          `json:"description"`
+	Image           string          `json:"image"`
+	ImageData       string          `json:"image_data,omitempty"`
+	ExternalURL     string          `json:"external_url,omitempty"`
+	AnimationURL    string          `json:"animation_url,omitempty"`
+	BackgroundColor string          `json:"background_color,omitempty"`
+	Decimals        *uint8          `json:"decimals,omitempty"`
+	Attributes      []Attribute     `json:"attributes,omitempty"`
+	Properties      map[string]any  `json:"properties,omitempty"`
+	Raw             json.RawMessage `json:"-"`
+}
+
+type Attribute struct {
+	TraitType   string `json:"trait_type"`
+	Value       any    `json:"value"`
+	DisplayType string `json:"display_type,omitempty"`
+}
@@ -0,0 +1,256 @@
+package permit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/dtome123/go-bcwe3/eth/constants"
+	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/erc20"
+	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/signer"
+	"github.com/dtome123/go-bcwe3/eth/types"
+	"github.com/dtome123/go-bcwe3/eth/verifier"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+var (
+	ErrDomainMismatch  = errors.New("could not reproduce the token DOMAIN_SEPARATOR")
+	ErrMalformedPermit = errors.New("malformed permit message")
+)
+
+// versionCandidates are tried when the token exposes neither eip712Domain() nor version().
+var versionCandidates = []string{"1", "2"}
+
+var permitTypes = []apitypes.Type{
+	{Name: "owner", Type: "address"},
+	{Name: "spender", Type: "address"},
+	{Name: "value", Type: "uint256"},
+	{Name: "nonce", Type: "uint256"},
+	{Name: "deadline", Type: "uint256"},
+}
+
+type eip2612 struct {
+	provider provider.Provider
+	address  string
+	erc20.ERC20
+}
+
+func NewEIP2612(address string, provider provider.Provider) (EIP2612, error) {
+
+	token, err := erc20.NewWithABI(address, provider, constants.ERC20PermitABI)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eip2612{
+		provider: provider,
+		address:  address,
+		ERC20:    token,
+	}, nil
+}
+
+func (p *eip2612) DomainSeparator(ctx context.Context) ([32]byte, error) {
+	result, err := p.ERC20.Call(ctx, "DOMAIN_SEPARATOR")
+
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	separator, ok := result.Index(0).Value.([32]byte)
+	if !ok {
+		return [32]byte{}, errors.New("value is not bytes32")
+	}
+
+	return separator, nil
+}
+
+func (p *eip2612) Nonces(ctx context.Context, owner string) (*big.Int, error) {
+	result, err := p.ERC20.Call(ctx, "nonces", common.HexToAddress(owner))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Index(0).AsBigInt()
+}
+
+// Domain prefers EIP-5267 eip712Domain(); otherwise it rebuilds the domain from
+// name(), version() (or common defaults) and the chain id, and keeps the candidate
+// whose hash equals DOMAIN_SEPARATOR.
+func (p *eip2612) Domain(ctx context.Context) (*apitypes.TypedDataDomain, error) {
+	if domain, ok := p.eip5267Domain(ctx); ok {
+		return domain, nil
+	}
+
+	separator, err := p.DomainSeparator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := p.Name()
+	if err != nil {
+		return nil, err
+	}
+
+	chainID, err := p.provider.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := versionCandidates
+	if result, err := p.ERC20.Call(ctx, "version"); err == nil {
+		if version, err := result.Index(0).AsString(); err == nil {
+			versions = []string{version}
+		}
+	}
+
+	for _, version := range versions {
+		domain := apitypes.TypedDataDomain{
+			Name:              name,
+			Version:           version,
+			ChainId:           (*math.HexOrDecimal256)(chainID),
+			VerifyingContract: common.HexToAddress(p.address).Hex(),
+		}
+
+		typedData := apitypes.TypedData{Types: apitypes.Types{"EIP712Domain": verifier.DomainType(domain)}}
+		hash, err := typedData.HashStruct("EIP712Domain", domain.Map())
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.Equal(hash, separator[:]) {
+			return &domain, nil
+		}
+	}
+
+	return nil, ErrDomainMismatch
+}
+
+func (p *eip2612) BuildPermit(ctx context.Context, owner string, spender string, value *big.Int, deadline *big.Int) (*apitypes.TypedData, error) {
+	domain, err := p.Domain(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := p.Nonces(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": verifier.DomainType(*domain),
+			"Permit":       permitTypes,
+		},
+		PrimaryType: "Permit",
+		Domain:      *domain,
+		Message: apitypes.TypedDataMessage{
+			"owner":    common.HexToAddress(owner).Hex(),
+			"spender":  common.HexToAddress(spender).Hex(),
+			"value":    value.String(),
+			"nonce":    nonce.String(),
+			"deadline": deadline.String(),
+		},
+	}, nil
+}
+
+func (p *eip2612) SignPermit(ctx context.Context, signer signer.Signer, spender string, value *big.Int, deadline *big.Int) (*SignedPermit, error) {
+	typedData, err := p.BuildPermit(ctx, signer.Address(), spender, value, deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	return signTypedData(signer, typedData)
+}
+
+func (p *eip2612) SubmitPermit(ctx context.Context, sender signer.Signer, permit *SignedPermit, opts *contract.TransactOpts) (*types.Tx, error) {
+	if permit == nil || permit.TypedData == nil {
+		return nil, ErrMalformedPermit
+	}
+	message := permit.TypedData.Message
+
+	spender, ok := message["spender"].(string)
+	if !ok || !common.IsHexAddress(spender) {
+		return nil, fmt.Errorf("%w: spender", ErrMalformedPermit)
+	}
+
+	value, err := messageUint(message, "value")
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, err := messageUint(message, "deadline")
+	if err != nil {
+		return nil, err
+	}
+
+	return p.ERC20.TransactWithSigner(ctx, sender, opts, "permit",
+		common.HexToAddress(permit.Owner),
+		common.HexToAddress(spender),
+		value,
+		deadline,
+		permit.V,
+		permit.R,
+		permit.S,
+	)
+}
+
+// messageUint reads a decimal string field of a permit message as written by BuildPermit.
+func messageUint(message apitypes.TypedDataMessage, field string) (*big.Int, error) {
+	text, ok := message[field].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedPermit, field)
+	}
+
+	value, ok := new(big.Int).SetString(text, 10)
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedPermit, field)
+	}
+
+	return value, nil
+}
+
+func (p *eip2612) eip5267Domain(ctx context.Context) (*apitypes.TypedDataDomain, bool) {
+	result, err := p.ERC20.Call(ctx, "eip712Domain")
+	if err != nil || result.Len() < 6 {
+		return nil, false
+	}
+
+	fields, ok := result.Index(0).Value.([1]byte)
+	if !ok {
+		return nil, false
+	}
+
+	domain := &apitypes.TypedDataDomain{}
+	if fields[0]&0x01 != 0 {
+		domain.Name, _ = result.Index(1).AsString()
+	}
+	if fields[0]&0x02 != 0 {
+		domain.Version, _ = result.Index(2).AsString()
+	}
+	if fields[0]&0x04 != 0 {
+		if chainID, err := result.Index(3).AsBigInt(); err == nil {
+			domain.ChainId = (*math.HexOrDecimal256)(chainID)
+		}
+	}
+	if fields[0]&0x08 != 0 {
+		if contract, err := result.Index(4).AsAddress(); err == nil {
+			domain.VerifyingContract = contract.Hex()
+		}
+	}
+	if fields[0]&0x10 != 0 {
+		if salt, ok := result.Index(5).Value.([32]byte); ok {
+			domain.Salt = hexutil.Encode(salt[:])
+		}
+	}
+
+	return domain, true
+}
@@ -0,0 +1,191 @@
+package permit
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/dtome123/go-bcwe3/eth/constants"
+	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/signer"
+	"github.com/dtome123/go-bcwe3/eth/verifier"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+var (
+	permitDetailsTypes = []apitypes.Type{
+		{Name: "token", Type: "address"},
+		{Name: "amount", Type: "uint160"},
+		{Name: "expiration", Type: "uint48"},
+		{Name: "nonce", Type: "uint48"},
+	}
+	permitSingleTypes = []apitypes.Type{
+		{Name: "details", Type: "PermitDetails"},
+		{Name: "spender", Type: "address"},
+		{Name: "sigDeadline", Type: "uint256"},
+	}
+	tokenPermissionsTypes = []apitypes.Type{
+		{Name: "token", Type: "address"},
+		{Name: "amount", Type: "uint256"},
+	}
+	permitTransferFromTypes = []apitypes.Type{
+		{Name: "permitted", Type: "TokenPermissions"},
+		{Name: "spender", Type: "address"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "deadline", Type: "uint256"},
+	}
+)
+
+type permit2 struct {
+	provider provider.Provider
+	address  string
+	contract contract.Contract
+}
+
+// NewPermit2 binds Permit2 at address, or at the canonical deployment when address is empty.
+func NewPermit2(address string, provider provider.Provider) (Permit2, error) {
+	if strings.TrimSpace(address) == "" {
+		address = constants.Permit2Address
+	}
+
+	contract, err := contract.NewContract(provider, address, constants.Permit2ABI)
+	if err != nil {
+		return nil, err
+	}
+
+	return &permit2{
+		provider: provider,
+		address:  common.HexToAddress(address).Hex(),
+		contract: contract,
+	}, nil
+}
+
+func (p *permit2) Address() string {
+	return p.address
+}
+
+func (p *permit2) Allowance(ctx context.Context, owner string, token string, spender string) (*Permit2Allowance, error) {
+	result, err := p.contract.Call(ctx, "allowance", common.HexToAddress(owner), common.HexToAddress(token), common.HexToAddress(spender))
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := result.Index(0).AsBigInt()
+	if err != nil {
+		return nil, err
+	}
+
+	expiration, err := result.Index(1).AsBigInt()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := result.Index(2).AsBigInt()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Permit2Allowance{
+		Amount:     amount,
+		Expiration: expiration.Uint64(),
+		Nonce:      nonce.Uint64(),
+	}, nil
+}
+
+func (p *permit2) BuildPermitSingle(ctx context.Context, owner string, permit *PermitSingle) (*apitypes.TypedData, error) {
+	nonce := permit.Nonce
+	if nonce == nil {
+		allowance, err := p.Allowance(ctx, owner, permit.Token, permit.Spender)
+		if err != nil {
+			return nil, err
+		}
+		nonce = new(big.Int).SetUint64(allowance.Nonce)
+	}
+
+	domain, err := p.domain(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain":  verifier.DomainType(domain),
+			"PermitSingle":  permitSingleTypes,
+			"PermitDetails": permitDetailsTypes,
+		},
+		PrimaryType: "PermitSingle",
+		Domain:      domain,
+		Message: apitypes.TypedDataMessage{
+			"details": map[string]interface{}{
+				"token":      common.HexToAddress(permit.Token).Hex(),
+				"amount":     permit.Amount.String(),
+				"expiration": new(big.Int).SetUint64(permit.Expiration).String(),
+				"nonce":      nonce.String(),
+			},
+			"spender":     common.HexToAddress(permit.Spender).Hex(),
+			"sigDeadline": permit.SigDeadline.String(),
+		},
+	}, nil
+}
+
+func (p *permit2) SignPermitSingle(ctx context.Context, signer signer.Signer, permit *PermitSingle) (*SignedPermit, error) {
+	typedData, err := p.BuildPermitSingle(ctx, signer.Address(), permit)
+	if err != nil {
+		return nil, err
+	}
+
+	return signTypedData(signer, typedData)
+}
+
+func (p *permit2) BuildPermitTransferFrom(ctx context.Context, permit *PermitTransferFrom) (*apitypes.TypedData, error) {
+	domain, err := p.domain(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain":       verifier.DomainType(domain),
+			"PermitTransferFrom": permitTransferFromTypes,
+			"TokenPermissions":   tokenPermissionsTypes,
+		},
+		PrimaryType: "PermitTransferFrom",
+		Domain:      domain,
+		Message: apitypes.TypedDataMessage{
+			"permitted": map[string]interface{}{
+				"token":  common.HexToAddress(permit.Token).Hex(),
+				"amount": permit.Amount.String(),
+			},
+			"spender":  common.HexToAddress(permit.Spender).Hex(),
+			"nonce":    permit.Nonce.String(),
+			"deadline": permit.Deadline.String(),
+		},
+	}, nil
+}
+
+func (p *permit2) SignPermitTransferFrom(ctx context.Context, signer signer.Signer, permit *PermitTransferFrom) (*SignedPermit, error) {
+	typedData, err := p.BuildPermitTransferFrom(ctx, permit)
+	if err != nil {
+		return nil, err
+	}
+
+	return signTypedData(signer, typedData)
+}
+
+// domain returns the Permit2 EIP-712 domain, which has no version field.
+func (p *permit2) domain(ctx context.Context) (apitypes.TypedDataDomain, error) {
+	chainID, err := p.provider.ChainID(ctx)
+	if err != nil {
+		return apitypes.TypedDataDomain{}, err
+	}
+
+	return apitypes.TypedDataDomain{
+		Name:              "Permit2",
+		ChainId:           (*math.HexOrDecimal256)(chainID),
+		VerifyingContract: p.address,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package permit
+
+import (
+	"github.com/dtome123/go-bcwe3/eth/signer"
+	"github.com/dtome123/go-bcwe3/eth/verifier"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+func (p *SignedPermit) Hex() string {
+	return hexutil.Encode(p.Signature)
+}
+
+// Verify checks the signature against Owner through the verifier package.
+func (p *SignedPermit) Verify() error {
+	return verifier.Verify(&verifier.VerifyRequest{
+		SignatureType: verifier.SignatureTypedData,
+		Payload:       p.TypedData,
+		Signature:     p.Hex(),
+		ExpectedAddr:  p.Owner,
+	})
+}
+
+func signTypedData(s signer.Signer, typedData *apitypes.TypedData) (*SignedPermit, error) {
+	sig, err := signer.SignTypedData(s, *typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := &SignedPermit{
+		Owner:     s.Address(),
+		TypedData: typedData,
+		Signature: sig,
+		V:         sig[64],
+	}
+	copy(signed.R[:], sig[:32])
+	copy(signed.S[:], sig[32:64])
+
+	return signed, nil
+}
@@ -0,0 +1,70 @@
+package permit
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/erc20"
+	"github.com/dtome123/go-bcwe3/eth/signer"
+	"github.com/dtome123/go-bcwe3/eth/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// EIP2612 is an ERC-20 token supporting gasless approvals through permit signatures.
+type EIP2612 interface {
+	erc20.ERC20
+	DomainSeparator(ctx context.Context) ([32]byte, error)
+	Nonces(ctx context.Context, owner string) (*big.Int, error)
+	// Domain discovers the EIP-712 domain of the token and checks it against DOMAIN_SEPARATOR.
+	Domain(ctx context.Context) (*apitypes.TypedDataDomain, error)
+	BuildPermit(ctx context.Context, owner string, spender string, value *big.Int, deadline *big.Int) (*apitypes.TypedData, error)
+	SignPermit(ctx context.Context, signer signer.Signer, spender string, value *big.Int, deadline *big.Int) (*SignedPermit, error)
+	// SubmitPermit sends the permit on-chain from sender, who pays the gas.
+	SubmitPermit(ctx context.Context, sender signer.Signer, permit *SignedPermit, opts *contract.TransactOpts) (*types.Tx, error)
+}
+
+// Permit2 builds and signs messages for the Uniswap Permit2 contract.
+type Permit2 interface {
+	Address() string
+	Allowance(ctx context.Context, owner string, token string, spender string) (*Permit2Allowance, error)
+	BuildPermitSingle(ctx context.Context, owner string, permit *PermitSingle) (*apitypes.TypedData, error)
+	SignPermitSingle(ctx context.Context, signer signer.Signer, permit *PermitSingle) (*SignedPermit, error)
+	BuildPermitTransferFrom(ctx context.Context, permit *PermitTransferFrom) (*apitypes.TypedData, error)
+	SignPermitTransferFrom(ctx context.Context, signer signer.Signer, permit *PermitTransferFrom) (*SignedPermit, error)
+}
+
+type Permit2Allowance struct {
+	Amount     *big.Int `json:"amount"`
+	Expiration uint64   `json:"expiration"`
+	Nonce      uint64   `json:"nonce"`
+}
+
+// PermitSingle is an AllowanceTransfer approval. A nil Nonce is read from Permit2.
+type PermitSingle struct {
+	Token       string
+	Amount      *big.Int
+	Expiration  uint64
+	Nonce       *big.Int
+	Spender     string
+	SigDeadline *big.Int
+}
+
+// PermitTransferFrom is a SignatureTransfer message. Permit2 nonces are unordered,
+// so the caller picks an unused Nonce.
+type PermitTransferFrom struct {
+	Token    string
+	Amount   *big.Int
+	Spender  string
+	Nonce    *big.Int
+	Deadline *big.Int
+}
+
+type SignedPermit struct {
+	Owner     string              `json:"owner"`
+	TypedData *apitypes.TypedData `json:"typed_data"`
+	Signature []byte              `json:"signature"`
+	V         uint8               `json:"v"`
+	R         [32]byte            `json:"r"`
+	S         [32]byte            `json:"s"`
+}
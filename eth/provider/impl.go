@@ -2,23 +2,39 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
+	"strings"
+	"sync"
 
+	"github.com/dtome123/go-bcwe3/eth/revert"
 	"github.com/dtome123/go-bcwe3/eth/types"
 	"github.com/dtome123/go-bcwe3/eth/utils"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	goethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
+var (
+	ErrTransactionNotReverted = errors.New("transaction did not revert")
+	ErrRevertNotReproduced    = errors.New("revert could not be reproduced")
+	ErrExecutionFailed        = errors.New("transaction failed without revert data")
+)
+
 type impl struct {
 	client *ethclient.Client
+	// abis holds the ABIs registered per lower-cased contract address.
+	abis   map[string]abi.ABI
+	abisMu sync.RWMutex
 }
 
 func NewProvider(dsn string) Provider {
@@ -29,6 +45,7 @@ func NewProvider(dsn string) Provider {
 
 	c := &impl{
 		client: client,
+		abis:   make(map[string]abi.ABI),
 	}
 
 	return c
@@ -420,3 +437,134 @@ func (l *impl) ListenBlock(handleFunc func(block *types.Block), errorChan chan<-
 		}
 	}
 }
+
+// WaitMined blocks until the transaction is included in a block and returns its receipt.
+func (e *impl) WaitMined(ctx context.Context, txHash string) (*types.Receipt, error) {
+	receipt, err := bind.WaitMined(ctx, e.client, common.HexToHash(txHash))
+	if err != nil {
+		return nil, err
+	}
+
+	return types.WrapReceipt(receipt), nil
+}
+
+// RegisterABI records the ABI of the contract at address so that
+// ReplayTransaction can decode custom errors of transactions sent to it
+// without the caller passing the ABI. Registration is explicit and entries
+// live as long as the provider; contract wrappers pass their own ABI instead.
+// ABIs registered for the same address are merged.
+func (e *impl) RegisterABI(address string, contractABI abi.ABI) {
+	key := strings.ToLower(common.HexToAddress(address).Hex())
+
+	e.abisMu.Lock()
+	defer e.abisMu.Unlock()
+
+	existing, ok := e.abis[key]
+	if !ok {
+		e.abis[key] = contractABI
+		return
+	}
+
+	merged := abi.ABI{
+		Constructor: existing.Constructor,
+		Methods:     make(map[string]abi.Method),
+		Events:      make(map[string]abi.Event),
+		Errors:      make(map[string]abi.Error),
+		Fallback:    existing.Fallback,
+		Receive:     existing.Receive,
+	}
+	for _, source := range []abi.ABI{contractABI, existing} {
+		for name, method := range source.Methods {
+			merged.Methods[name] = method
+		}
+		for name, event := range source.Events {
+			merged.Events[name] = event
+		}
+		for name, abiErr := range source.Errors {
+			merged.Errors[name] = abiErr
+		}
+	}
+	e.abis[key] = merged
+}
+
+// ReplayTransaction explains why a mined transaction failed. It re-executes the
+// transaction with eth_call at its parent block using the original from, to,
+// value, data and gas; when that does not reproduce the revert (e.g. the
+// failure depended on earlier transactions in the block, or the node has
+// pruned the state) it falls back to debug_traceTransaction. Custom errors are
+// decoded with the ABI registered for the recipient and any abis given.
+func (e *impl) ReplayTransaction(ctx context.Context, txHash string, abis ...abi.ABI) (*revert.RevertError, error) {
+	hash := common.HexToHash(txHash)
+
+	receipt, err := e.client.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status == goethTypes.ReceiptStatusSuccessful {
+		return nil, ErrTransactionNotReverted
+	}
+
+	tx, _, err := e.client.TransactionByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.To() != nil {
+		e.abisMu.RLock()
+		registered, ok := e.abis[strings.ToLower(tx.To().Hex())]
+		e.abisMu.RUnlock()
+		if ok {
+			abis = append([]abi.ABI{registered}, abis...)
+		}
+	}
+
+	msg := ethereum.CallMsg{
+		From:  common.HexToAddress(utils.GetFromAddressTx(tx)),
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+
+	parent := new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+	if _, err := e.client.CallContract(ctx, msg, parent); err != nil {
+		if reason, ok := revert.FromError(err, abis...); ok {
+			return reason, nil
+		}
+	}
+
+	return e.traceRevert(ctx, hash, abis)
+}
+
+// callFrame is the top-level frame returned by the callTracer.
+type callFrame struct {
+	Output       string `json:"output"`
+	Error        string `json:"error"`
+	RevertReason string `json:"revertReason"`
+}
+
+func (e *impl) traceRevert(ctx context.Context, hash common.Hash, abis []abi.ABI) (*revert.RevertError, error) {
+	var frame callFrame
+	err := e.client.Client().CallContext(ctx, &frame, "debug_traceTransaction", hash, map[string]any{
+		"tracer": "callTracer",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRevertNotReproduced, err)
+	}
+
+	if frame.Error == "" {
+		return nil, ErrRevertNotReproduced
+	}
+	if !strings.Contains(frame.Error, "revert") {
+		return nil, fmt.Errorf("%w: %s", ErrExecutionFailed, frame.Error)
+	}
+
+	data, _ := hexutil.Decode(frame.Output)
+	reason := revert.Decode(data, abis...)
+	if reason.Kind == revert.KindEmpty && frame.RevertReason != "" {
+		reason.Kind = revert.KindError
+		reason.Reason = frame.RevertReason
+	}
+
+	return reason, nil
+}
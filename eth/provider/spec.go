@@ -4,8 +4,10 @@ import (
 	"context"
 	"math/big"
 
+	"github.com/dtome123/go-bcwe3/eth/revert"
 	"github.com/dtome123/go-bcwe3/eth/types"
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	goethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -63,4 +65,7 @@ type Provider interface {
 	IsBlockFinalized(ctx context.Context, blockNumber *big.Int) (bool, error)
 	GetCompleteTransaction(ctx context.Context, tx *types.Tx) (*types.CompleteTx, error)
 	ListenBlock(handleFunc func(block *types.Block), errorChan chan<- error)
+	WaitMined(ctx context.Context, txHash string) (*types.Receipt, error)
+	RegisterABI(address string, contractABI abi.ABI)
+	ReplayTransaction(ctx context.Context, txHash string, abis ...abi.ABI) (*revert.RevertError, error)
 }
@@ -0,0 +1,266 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/scanner"
+	"github.com/dtome123/go-bcwe3/eth/utils"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const maxChainDepth = 10
+
+var (
+	// EIP-1967 slots are keccak256("eip1967.proxy.<name>") - 1.
+	implementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	adminSlot          = common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+	beaconSlot         = common.HexToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+	// zeppelinOSSlot is keccak256("org.zeppelinos.proxy.implementation").
+	zeppelinOSSlot = common.HexToHash("0x7050c9e0f4ca769c69bd3a8ef740bc37934f8e2c036e5a723fd8ee048ed3f8c3")
+
+	eip1167Prefix = common.FromHex("0x363d3d373d3d3d363d73")
+	eip1167Suffix = common.FromHex("0x5af43d82803e903d91602b57fd5bf3")
+
+	implementationSelector = selector("implementation()")
+	proxiableUUIDSelector  = selector("proxiableUUID()")
+	masterCopySelector     = selector("masterCopy()")
+	proxyTypeSelector      = selector("proxyType()")
+
+	upgradedEventID       = crypto.Keccak256Hash([]byte("Upgraded(address)"))
+	beaconUpgradedEventID = crypto.Keccak256Hash([]byte("BeaconUpgraded(address)"))
+
+	ErrProxyLoop = errors.New("proxy chain contains a loop")
+)
+
+type impl struct {
+	provider provider.Provider
+	scanner  scanner.LogScanner
+}
+
+func NewResolver(provider provider.Provider) Resolver {
+	return &impl{
+		provider: provider,
+		scanner:  scanner.NewLogScanner(provider, nil),
+	}
+}
+
+func (r *impl) Resolve(ctx context.Context, address string, blockNumber *big.Int) (*Resolution, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid contract address: %s", address)
+	}
+
+	current := common.HexToAddress(address)
+	resolution := &Resolution{
+		Address:        current.Hex(),
+		Implementation: current.Hex(),
+	}
+
+	visited := map[common.Address]bool{current: true}
+
+	for depth := 0; depth < maxChainDepth; depth++ {
+		hop, err := r.resolveHop(ctx, current, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if hop == nil {
+			break
+		}
+
+		resolution.Chain = append(resolution.Chain, hop)
+		resolution.Implementation = hop.Implementation
+
+		current = common.HexToAddress(hop.Implementation)
+		if visited[current] {
+			return resolution, ErrProxyLoop
+		}
+		visited[current] = true
+	}
+
+	return resolution, nil
+}
+
+// resolveHop returns nil when address is not a recognised proxy.
+func (r *impl) resolveHop(ctx context.Context, address common.Address, blockNumber *big.Int) (*Hop, error) {
+	code, err := r.provider.CodeAt(ctx, address.Hex(), blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) == 0 {
+		return nil, nil
+	}
+
+	hop := &Hop{Proxy: address.Hex()}
+
+	if implementation, ok := parseMinimalProxy(code); ok {
+		hop.Kind = KindEIP1167
+		hop.Implementation = implementation.Hex()
+		return hop, nil
+	}
+
+	implementation, err := r.readSlot(ctx, address, implementationSlot, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if implementation != (common.Address{}) {
+		admin, err := r.readSlot(ctx, address, adminSlot, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		hop.Kind = KindEIP1967
+		hop.Implementation = implementation.Hex()
+		if admin != (common.Address{}) {
+			hop.Admin = admin.Hex()
+		} else if r.isUUPS(ctx, implementation, blockNumber) {
+			hop.Kind = KindUUPS
+		}
+		return hop, nil
+	}
+
+	beacon, err := r.readSlot(ctx, address, beaconSlot, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if beacon != (common.Address{}) {
+		implementation, ok := r.callAddress(ctx, beacon, implementationSelector, blockNumber)
+		if !ok {
+			return nil, fmt.Errorf("beacon %s did not return an implementation", beacon.Hex())
+		}
+
+		hop.Kind = KindBeacon
+		hop.Beacon = beacon.Hex()
+		hop.Implementation = implementation.Hex()
+		return hop, nil
+	}
+
+	implementation, err = r.readSlot(ctx, address, zeppelinOSSlot, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if implementation != (common.Address{}) {
+		hop.Kind = KindZeppelinOS
+		hop.Implementation = implementation.Hex()
+		return hop, nil
+	}
+
+	// beacons and factories also expose implementation(), so EIP-897 is only
+	// accepted for contracts that actually delegate
+	if implementation, ok := r.callAddress(ctx, address, implementationSelector, blockNumber); ok && r.hasCode(ctx, implementation, blockNumber) && r.delegates(ctx, address, code, blockNumber) {
+		hop.Kind = KindEIP897
+		hop.Implementation = implementation.Hex()
+		return hop, nil
+	}
+
+	if implementation, ok := r.callAddress(ctx, address, masterCopySelector, blockNumber); ok && r.hasCode(ctx, implementation, blockNumber) && r.delegates(ctx, address, code, blockNumber) {
+		hop.Kind = KindGnosisSafe
+		hop.Implementation = implementation.Hex()
+		return hop, nil
+	}
+
+	return nil, nil
+}
+
+func (r *impl) ImplementationHistory(ctx context.Context, address string, fromBlock *big.Int, toBlock *big.Int) ([]*Upgrade, error) {
+	logs, err := r.scanner.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Addresses: []common.Address{common.HexToAddress(address)},
+		Topics:    [][]common.Hash{{upgradedEventID, beaconUpgradedEventID}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	upgrades := make([]*Upgrade, 0, len(logs))
+	for _, log := range logs {
+		if log.Removed || len(log.Topics) != 2 {
+			continue
+		}
+
+		upgrade := &Upgrade{
+			BlockNumber: log.BlockNumber,
+			TxHash:      log.TxHash,
+			LogIndex:    log.Index,
+		}
+
+		target := common.BytesToAddress(log.Topics[1].Bytes()).Hex()
+		if log.Topics[0] == upgradedEventID {
+			upgrade.Implementation = target
+		} else {
+			upgrade.Beacon = target
+		}
+
+		upgrades = append(upgrades, upgrade)
+	}
+
+	return upgrades, nil
+}
+
+func (r *impl) readSlot(ctx context.Context, address common.Address, slot common.Hash, blockNumber *big.Int) (common.Address, error) {
+	value, err := r.provider.StorageAt(ctx, address.Hex(), slot.Hex(), blockNumber)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return common.BytesToAddress(value), nil
+}
+
+// callAddress calls a parameterless function returning an address, reporting false on revert or zero address.
+func (r *impl) callAddress(ctx context.Context, to common.Address, sel []byte, blockNumber *big.Int) (common.Address, bool) {
+	output, err := r.provider.CallContract(ctx, ethereum.CallMsg{To: &to, Data: sel}, blockNumber)
+	if err != nil || len(output) != 32 {
+		return common.Address{}, false
+	}
+
+	address := common.BytesToAddress(output)
+	return address, address != (common.Address{})
+}
+
+// isUUPS checks that the implementation reports the EIP-1967 slot from proxiableUUID (EIP-1822).
+func (r *impl) isUUPS(ctx context.Context, implementation common.Address, blockNumber *big.Int) bool {
+	output, err := r.provider.CallContract(ctx, ethereum.CallMsg{To: &implementation, Data: proxiableUUIDSelector}, blockNumber)
+	if err != nil || len(output) != 32 {
+		return false
+	}
+
+	return common.BytesToHash(output) == implementationSlot
+}
+
+// delegates reports whether the contract declares itself a forwarding or
+// upgradeable proxy through EIP-897 proxyType(), or its code contains DELEGATECALL.
+func (r *impl) delegates(ctx context.Context, address common.Address, code []byte, blockNumber *big.Int) bool {
+	output, err := r.provider.CallContract(ctx, ethereum.CallMsg{To: &address, Data: proxyTypeSelector}, blockNumber)
+	if err == nil && len(output) == 32 {
+		if proxyType := new(big.Int).SetBytes(output); proxyType.Cmp(big.NewInt(1)) == 0 || proxyType.Cmp(big.NewInt(2)) == 0 {
+			return true
+		}
+	}
+
+	return utils.HasDelegateCall(code)
+}
+
+func (r *impl) hasCode(ctx context.Context, address common.Address, blockNumber *big.Int) bool {
+	code, err := r.provider.CodeAt(ctx, address.Hex(), blockNumber)
+	return err == nil && len(code) > 0
+}
+
+func parseMinimalProxy(code []byte) (common.Address, bool) {
+	if len(code) != len(eip1167Prefix)+common.AddressLength+len(eip1167Suffix) ||
+		!bytes.HasPrefix(code, eip1167Prefix) || !bytes.HasSuffix(code, eip1167Suffix) {
+		return common.Address{}, false
+	}
+
+	return common.BytesToAddress(code[len(eip1167Prefix) : len(eip1167Prefix)+common.AddressLength]), true
+}
+
+func selector(signature string) []byte {
+	return crypto.Keccak256([]byte(signature))[:4]
+}
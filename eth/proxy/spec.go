@@ -0,0 +1,53 @@
+package proxy
+
+import (
+	"context"
+	"math/big"
+)
+
+type Resolver interface {
+	// Resolve follows a chain of proxies starting at address and returns every hop.
+	// A nil blockNumber reads the latest state.
+	Resolve(ctx context.Context, address string, blockNumber *big.Int) (*Resolution, error)
+	// ImplementationHistory lists EIP-1967 Upgraded and BeaconUpgraded events of the proxy.
+	ImplementationHistory(ctx context.Context, address string, fromBlock *big.Int, toBlock *big.Int) ([]*Upgrade, error)
+}
+
+type Kind string
+
+const (
+	KindEIP1167    Kind = "EIP-1167"
+	KindEIP1967    Kind = "EIP-1967"
+	KindUUPS       Kind = "UUPS"
+	KindBeacon     Kind = "beacon"
+	KindZeppelinOS Kind = "ZeppelinOS"
+	KindEIP897     Kind = "EIP-897"
+	KindGnosisSafe Kind = "GnosisSafe"
+)
+
+type Hop struct {
+	Proxy          string `json:"proxy"`
+	Kind           Kind   `json:"kind"`
+	Implementation string `json:"implementation"`
+	Admin          string `json:"admin,omitempty"`
+	Beacon         string `json:"beacon,omitempty"`
+}
+
+type Resolution struct {
+	Address string `json:"address"`
+	// Implementation is the last contract in the chain, equal to Address when it is not a proxy.
+	Implementation string `json:"implementation"`
+	Chain          []*Hop `json:"chain"`
+}
+
+func (r *Resolution) IsProxy() bool {
+	return len(r.Chain) > 0
+}
+
+type Upgrade struct {
+	Implementation string `json:"implementation,omitempty"`
+	Beacon         string `json:"beacon,omitempty"`
+	BlockNumber    uint64 `json:"block_number"`
+	TxHash         string `json:"transaction_hash"`
+	LogIndex       uint   `json:"log_index"`
+}
@@ -0,0 +1,333 @@
+package registry
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dtome123/go-bcwe3/eth/constants"
+	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	goethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	ErrNoInput          = errors.New("no calldata to decode")
+	ErrContractCreation = errors.New("transaction is a contract creation")
+	ErrUnknownSelector  = errors.New("no method registered for selector")
+	ErrUnknownEvent     = errors.New("no event registered for topic")
+	ErrUndecodable      = errors.New("no registered candidate decodes the data")
+	ErrInvalidSignature = errors.New("invalid text signature")
+	ErrSelectorMismatch = errors.New("selector does not match signature")
+)
+
+type impl struct {
+	mu      sync.RWMutex
+	methods map[[4]byte][]abi.Method
+	events  map[common.Hash][]eventEntry
+	seen    map[string]bool
+}
+
+type eventEntry struct {
+	event abi.Event
+	// guessIndexed is set for text signatures without indexed markers; the
+	// leading arguments are then treated as indexed to match the log's topics.
+	guessIndexed bool
+}
+
+// NewRegistry returns a registry seeded with the ERC-20, ERC-721 and ERC-1155 ABIs.
+func NewRegistry() Registry {
+	r := &impl{
+		methods: make(map[[4]byte][]abi.Method),
+		events:  make(map[common.Hash][]eventEntry),
+		seen:    make(map[string]bool),
+	}
+
+	for _, abiData := range []string{constants.ERC20ABI, constants.ERC721ABI, constants.ERC1155ABI} {
+		if parsed, err := abi.JSON(strings.NewReader(abiData)); err == nil {
+			r.Register(parsed)
+		}
+	}
+
+	return r
+}
+
+func (r *impl) Register(contractABI abi.ABI) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range sortedKeys(contractABI.Methods) {
+		r.addMethod(contractABI.Methods[name])
+	}
+	for _, name := range sortedKeys(contractABI.Events) {
+		r.addEvent(contractABI.Events[name], false)
+	}
+}
+
+func (r *impl) RegisterJSON(abiData string) error {
+	parsed, err := abi.JSON(strings.NewReader(abiData))
+	if err != nil {
+		return err
+	}
+
+	r.Register(parsed)
+	return nil
+}
+
+func (r *impl) RegisterSignature(signature string) error {
+	return r.addSignature(signature, nil)
+}
+
+func (r *impl) LoadSignatures(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	line := 0
+
+	var errs []error
+	for scanner.Scan() {
+		line++
+		if err := r.loadSignature(scanner.Text()); err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// loadSignature registers one line of a signature database.
+func (r *impl) loadSignature(text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.HasPrefix(text, "#") {
+		return nil
+	}
+
+	var id []byte
+	if strings.HasPrefix(text, "0x") {
+		end := strings.IndexAny(text, " \t,")
+		if end < 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidSignature, text)
+		}
+		decoded, err := hexutil.Decode(text[:end])
+		if err != nil {
+			return err
+		}
+		id = decoded
+		text = strings.TrimSpace(text[end+1:])
+	}
+
+	// 32-byte ids are event topics, as in 4byte.directory event dumps.
+	if len(id) == common.HashLength && !strings.HasPrefix(text, "event ") {
+		text = "event " + text
+	}
+
+	return r.addSignature(text, id)
+}
+
+func (r *impl) LoadSignatureFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return r.LoadSignatures(file)
+}
+
+func (r *impl) DecodeInput(input []byte) (*DecodedCall, error) {
+	if len(input) < 4 {
+		return nil, ErrNoInput
+	}
+
+	var selector [4]byte
+	copy(selector[:], input[:4])
+
+	r.mu.RLock()
+	candidates := r.methods[selector]
+	r.mu.RUnlock()
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSelector, hexutil.Encode(selector[:]))
+	}
+
+	var loose *DecodedCall
+	for _, method := range candidates {
+		values, err := method.Inputs.Unpack(input[4:])
+		if err != nil {
+			continue
+		}
+
+		call := &DecodedCall{
+			Name:      method.RawName,
+			Signature: method.Sig,
+			Selector:  selector,
+			Args:      make(contract.ContractResults, len(values)),
+		}
+		for i, value := range values {
+			call.Args[i] = contract.ContractResult{Name: method.Inputs[i].Name, Value: value}
+		}
+
+		// Padding and trailing bytes make several layouts unpack; an exact
+		// re-encoding identifies the intended one.
+		if packed, err := method.Inputs.Pack(values...); err == nil && bytes.Equal(packed, input[4:]) {
+			return call, nil
+		}
+		if loose == nil {
+			loose = call
+		}
+	}
+
+	if loose != nil {
+		return loose, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUndecodable, hexutil.Encode(selector[:]))
+}
+
+func (r *impl) DecodeTx(tx *types.Tx) (*DecodedCall, error) {
+	if tx == nil || tx.Origin == nil {
+		return nil, ErrNoInput
+	}
+	if tx.Origin.To() == nil {
+		return nil, ErrContractCreation
+	}
+
+	return r.DecodeInput(tx.Origin.Data())
+}
+
+func (r *impl) DecodeLog(log *types.Log) (*DecodedEvent, error) {
+	if log == nil || len(log.Topics) == 0 {
+		return nil, ErrUnknownEvent
+	}
+
+	r.mu.RLock()
+	candidates := r.events[log.Topics[0]]
+	r.mu.RUnlock()
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, log.Topics[0].Hex())
+	}
+
+	raw := goethTypes.Log{Topics: log.Topics, Data: log.Data}
+
+	for _, entry := range candidates {
+		event := entry.event
+		if entry.guessIndexed {
+			var ok bool
+			if event, ok = withLeadingIndexed(event, len(log.Topics)-1); !ok {
+				continue
+			}
+		}
+
+		args, err := contract.UnpackEvent(event, raw)
+		if err != nil {
+			continue
+		}
+
+		return &DecodedEvent{
+			Name:      event.RawName,
+			Signature: event.Sig,
+			Address:   log.Address,
+			Args:      args,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUndecodable, log.Topics[0].Hex())
+}
+
+func (r *impl) addSignature(signature string, id []byte) error {
+	kind, name, inputs, explicitIndexed, err := parseSignature(signature)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if kind == "event" {
+		event := abi.NewEvent(name, name, false, inputs)
+		if id != nil && !bytes.Equal(id, event.ID[:]) {
+			return fmt.Errorf("%w: %s", ErrSelectorMismatch, signature)
+		}
+		r.addEvent(event, !explicitIndexed)
+		return nil
+	}
+
+	method := abi.NewMethod(name, name, abi.Function, "nonpayable", false, false, inputs, nil)
+	if id != nil && !bytes.Equal(id, method.ID) {
+		return fmt.Errorf("%w: %s", ErrSelectorMismatch, signature)
+	}
+	r.addMethod(method)
+	return nil
+}
+
+func (r *impl) addMethod(method abi.Method) {
+	key := "function " + method.Sig
+	if r.seen[key] {
+		return
+	}
+	r.seen[key] = true
+
+	var selector [4]byte
+	copy(selector[:], method.ID)
+	r.methods[selector] = append(r.methods[selector], method)
+}
+
+// addEvent keeps events that share a signature but index different arguments,
+// such as the ERC-20 and ERC-721 Transfer events.
+func (r *impl) addEvent(event abi.Event, guessIndexed bool) {
+	if event.Anonymous {
+		return
+	}
+
+	mask := make([]byte, len(event.Inputs))
+	for i, input := range event.Inputs {
+		mask[i] = '0'
+		if input.Indexed {
+			mask[i] = '1'
+		}
+	}
+
+	key := fmt.Sprintf("event %s %s %t", event.Sig, mask, guessIndexed)
+	if r.seen[key] {
+		return
+	}
+	r.seen[key] = true
+
+	r.events[event.ID] = append(r.events[event.ID], eventEntry{event: event, guessIndexed: guessIndexed})
+}
+
+// withLeadingIndexed marks the first n inputs of event as indexed.
+func withLeadingIndexed(event abi.Event, n int) (abi.Event, bool) {
+	if n > len(event.Inputs) {
+		return event, false
+	}
+
+	inputs := make(abi.Arguments, len(event.Inputs))
+	for i, input := range event.Inputs {
+		input.Indexed = i < n
+		inputs[i] = input
+	}
+	event.Inputs = inputs
+
+	return event, true
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
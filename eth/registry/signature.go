@@ -0,0 +1,185 @@
+package registry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// parseSignature parses a human-readable signature such as
+// "function transfer(address to, uint256 amount) returns (bool)" or
+// "event Transfer(address indexed,address indexed,uint256)". The keyword is
+// optional and defaults to function; anything after the parameter list is ignored.
+func parseSignature(signature string) (kind string, name string, inputs abi.Arguments, explicitIndexed bool, err error) {
+	text := strings.TrimSpace(signature)
+	kind = "function"
+
+	for _, keyword := range []string{"function", "event"} {
+		if strings.HasPrefix(text, keyword+" ") {
+			kind = keyword
+			text = strings.TrimSpace(text[len(keyword):])
+		}
+	}
+
+	open := strings.Index(text, "(")
+	if open <= 0 {
+		return "", "", nil, false, fmt.Errorf("%w: %s", ErrInvalidSignature, signature)
+	}
+	closing := matchingParen(text, open)
+	if closing < 0 {
+		return "", "", nil, false, fmt.Errorf("%w: %s", ErrInvalidSignature, signature)
+	}
+
+	name = strings.TrimSpace(text[:open])
+	if !isIdentifier(name) {
+		return "", "", nil, false, fmt.Errorf("%w: %s", ErrInvalidSignature, signature)
+	}
+
+	for i, param := range splitParams(text[open+1 : closing]) {
+		typeName, rest, ok := cutType(param)
+		if !ok {
+			return "", "", nil, false, fmt.Errorf("%w: %s", ErrInvalidSignature, signature)
+		}
+
+		marshaling, ok := toMarshaling(typeName, fmt.Sprintf("arg%d", i))
+		if !ok {
+			return "", "", nil, false, fmt.Errorf("%w: %s", ErrInvalidSignature, signature)
+		}
+
+		argType, err := abi.NewType(marshaling.Type, "", marshaling.Components)
+		if err != nil {
+			return "", "", nil, false, fmt.Errorf("%w: %s: %w", ErrInvalidSignature, signature, err)
+		}
+
+		argument := abi.Argument{Type: argType}
+		for _, word := range strings.Fields(rest) {
+			switch {
+			case word == "indexed" && kind == "event":
+				argument.Indexed = true
+				explicitIndexed = true
+			case word == "memory" || word == "calldata" || word == "storage":
+			case isIdentifier(word):
+				argument.Name = word
+			default:
+				return "", "", nil, false, fmt.Errorf("%w: %s", ErrInvalidSignature, signature)
+			}
+		}
+
+		inputs = append(inputs, argument)
+	}
+
+	return kind, name, inputs, explicitIndexed, nil
+}
+
+// toMarshaling converts a type such as "uint256[]" or "(address,(uint8,bytes))[2]"
+// to its JSON ABI form. Tuple components are named after their position unless
+// the signature names them, since go-ethereum builds Go structs from the names.
+func toMarshaling(typeName string, name string) (abi.ArgumentMarshaling, bool) {
+	typeName = strings.TrimPrefix(typeName, "tuple")
+	if !strings.HasPrefix(typeName, "(") {
+		return abi.ArgumentMarshaling{Name: name, Type: typeName}, typeName != ""
+	}
+
+	closing := matchingParen(typeName, 0)
+	if closing < 0 {
+		return abi.ArgumentMarshaling{}, false
+	}
+
+	marshaling := abi.ArgumentMarshaling{Name: name, Type: "tuple" + typeName[closing+1:]}
+	for i, param := range splitParams(typeName[1:closing]) {
+		componentType, rest, ok := cutType(param)
+		if !ok {
+			return abi.ArgumentMarshaling{}, false
+		}
+
+		componentName := fmt.Sprintf("arg%d", i)
+		if fields := strings.Fields(rest); len(fields) > 0 && isIdentifier(fields[len(fields)-1]) {
+			componentName = fields[len(fields)-1]
+		}
+
+		component, ok := toMarshaling(componentType, componentName)
+		if !ok {
+			return abi.ArgumentMarshaling{}, false
+		}
+		marshaling.Components = append(marshaling.Components, component)
+	}
+
+	return marshaling, true
+}
+
+// cutType splits a parameter into its type and the words that follow it.
+func cutType(param string) (string, string, bool) {
+	param = strings.TrimSpace(param)
+	if param == "" {
+		return "", "", false
+	}
+
+	end := strings.IndexAny(param, " \t")
+	if strings.HasPrefix(param, "(") || strings.HasPrefix(param, "tuple(") {
+		closing := matchingParen(param, strings.Index(param, "("))
+		if closing < 0 {
+			return "", "", false
+		}
+		end = closing + 1 + strings.IndexAny(param[closing+1:]+" ", " \t")
+	}
+	if end < 0 {
+		return param, "", true
+	}
+
+	return param[:end], param[end:], true
+}
+
+// splitParams splits a parameter list on the commas that are not inside a tuple.
+func splitParams(list string) []string {
+	if strings.TrimSpace(list) == "" {
+		return nil
+	}
+
+	var params []string
+	depth, start := 0, 0
+	for i, c := range list {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				params = append(params, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(params, list[start:])
+}
+
+func matchingParen(text string, open int) int {
+	depth := 0
+	for i := open; i < len(text); i++ {
+		switch text[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isIdentifier(word string) bool {
+	if word == "" {
+		return false
+	}
+	for i, c := range word {
+		if c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (i > 0 && c >= '0' && c <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
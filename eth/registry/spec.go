@@ -0,0 +1,56 @@
+package registry
+
+import (
+	"io"
+
+	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// Registry maps 4-byte selectors and event topic0 hashes to the ABIs that
+// define them and decodes raw calldata and logs without knowing the contract.
+type Registry interface {
+	// Register adds every method and event of contractABI.
+	Register(contractABI abi.ABI)
+	// RegisterJSON parses abiData and registers it.
+	RegisterJSON(abiData string) error
+	// RegisterSignature adds a text signature such as "transfer(address,uint256)"
+	// or "event Transfer(address indexed,address indexed,uint256)".
+	RegisterSignature(signature string) error
+	// LoadSignatures reads a signature database, one signature per line,
+	// optionally preceded by its hex selector or topic ("0xa9059cbb transfer(address,uint256)").
+	// Blank lines and lines starting with # are ignored. Lines that do not
+	// parse or whose selector does not match are skipped; their errors are
+	// returned joined once the whole input has been read.
+	LoadSignatures(r io.Reader) error
+	// LoadSignatureFile is LoadSignatures for a file on disk.
+	LoadSignatureFile(path string) error
+
+	// DecodeInput decodes calldata. When several methods share the selector the
+	// one whose re-encoding reproduces the input exactly is preferred.
+	DecodeInput(input []byte) (*DecodedCall, error)
+	// DecodeTx decodes the input of tx.
+	DecodeTx(tx *types.Tx) (*DecodedCall, error)
+	// DecodeLog decodes log with the first registered event matching its
+	// topic0 and number of indexed topics.
+	DecodeLog(log *types.Log) (*DecodedEvent, error)
+}
+
+type DecodedCall struct {
+	Name string
+	// Signature is the canonical signature, e.g. "transfer(address,uint256)".
+	Signature string
+	Selector  [4]byte
+	// Args holds the arguments in declaration order; unnamed arguments have an empty Name.
+	Args contract.ContractResults
+}
+
+type DecodedEvent struct {
+	Name      string
+	Signature string
+	Address   string
+	// Args holds indexed and non-indexed arguments in declaration order.
+	// Indexed dynamic values are only available as their topic hash.
+	Args contract.ContractResults
+}
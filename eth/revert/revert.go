@@ -0,0 +1,223 @@
+// Package revert decodes EVM revert data into Error(string), Panic(uint256)
+// and ABI custom errors.
+package revert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0} // Error(string)
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71} // Panic(uint256)
+)
+
+// PanicReasons maps Solidity panic codes to their meaning.
+var PanicReasons = map[uint64]string{
+	0x00: "generic compiler inserted panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to uninitialized internal function",
+}
+
+type Kind int
+
+const (
+	// KindEmpty is a revert without data, e.g. `revert()` or `require(cond)`.
+	KindEmpty Kind = iota
+	// KindError is `revert("reason")` / `require(cond, "reason")`.
+	KindError
+	// KindPanic is a Solidity panic such as an overflow or failed assert.
+	KindPanic
+	// KindCustom is an ABI custom error found in one of the supplied ABIs.
+	KindCustom
+	// KindUnknown is revert data that could not be decoded.
+	KindUnknown
+)
+
+type RevertError struct {
+	Kind Kind
+	// Data is the raw revert data.
+	Data []byte
+	// Reason is the Error(string) message.
+	Reason string
+	// PanicCode and PanicReason describe a Panic(uint256).
+	PanicCode   *big.Int
+	PanicReason string
+	// Name and Signature identify a custom error, e.g. "ERC20InsufficientBalance"
+	// and "ERC20InsufficientBalance(address,uint256,uint256)".
+	Name      string
+	Signature string
+	// Args holds the custom error arguments by name; unnamed arguments are
+	// keyed "arg0", "arg1", ... Values holds them in declaration order.
+	Args   map[string]any
+	Values []any
+
+	argNames []string
+	cause    error
+}
+
+func (e *RevertError) Error() string {
+	switch e.Kind {
+	case KindError:
+		return "execution reverted: " + e.Reason
+	case KindPanic:
+		return fmt.Sprintf("execution reverted: panic 0x%x (%s)", e.PanicCode, e.PanicReason)
+	case KindCustom:
+		return "execution reverted: " + e.describeCustom()
+	case KindUnknown:
+		return "execution reverted: unknown error " + hexutil.Encode(e.Data)
+	default:
+		return "execution reverted"
+	}
+}
+
+// Unwrap returns the node error the revert was extracted from, if any.
+func (e *RevertError) Unwrap() error {
+	return e.cause
+}
+
+// Selector returns the first four bytes of the revert data.
+func (e *RevertError) Selector() []byte {
+	if len(e.Data) < 4 {
+		return nil
+	}
+	return e.Data[:4]
+}
+
+func (e *RevertError) describeCustom() string {
+	parts := make([]string, len(e.Values))
+	for i, value := range e.Values {
+		parts[i] = fmt.Sprintf("%s=%v", e.argNames[i], value)
+	}
+	return e.Name + "(" + strings.Join(parts, ", ") + ")"
+}
+
+// Decode decodes revert data. Custom errors are looked up in abis.
+func Decode(data []byte, abis ...abi.ABI) *RevertError {
+	e := &RevertError{Kind: KindUnknown, Data: data}
+
+	switch {
+	case len(data) == 0:
+		e.Kind = KindEmpty
+
+	case bytes.HasPrefix(data, errorSelector):
+		if reason, err := abi.UnpackRevert(data); err == nil {
+			e.Kind = KindError
+			e.Reason = reason
+		}
+
+	case bytes.HasPrefix(data, panicSelector) && len(data) == 36:
+		e.Kind = KindPanic
+		e.PanicCode = new(big.Int).SetBytes(data[4:])
+		e.PanicReason = "unknown panic code"
+		if e.PanicCode.IsUint64() {
+			if reason, ok := PanicReasons[e.PanicCode.Uint64()]; ok {
+				e.PanicReason = reason
+			}
+		}
+
+	case len(data) >= 4:
+		decodeCustom(e, abis)
+	}
+
+	return e
+}
+
+func decodeCustom(e *RevertError, abis []abi.ABI) {
+	for _, contractABI := range abis {
+		for _, abiErr := range contractABI.Errors {
+			if !bytes.Equal(abiErr.ID[:4], e.Data[:4]) {
+				continue
+			}
+
+			values, err := abiErr.Inputs.Unpack(e.Data[4:])
+			if err != nil {
+				continue
+			}
+
+			e.Kind = KindCustom
+			e.Name = abiErr.Name
+			e.Signature = abiErr.Sig
+			e.Values = values
+			e.Args = make(map[string]any, len(values))
+			e.argNames = make([]string, len(values))
+			for i, value := range values {
+				name := abiErr.Inputs[i].Name
+				if name == "" {
+					name = fmt.Sprintf("arg%d", i)
+				}
+				e.Args[name] = value
+				e.argNames[i] = name
+			}
+			return
+		}
+	}
+}
+
+// FromError extracts the revert from an error returned by eth_call or
+// eth_estimateGas and decodes it with abis. It reports false when err is not
+// an execution revert.
+func FromError(err error, abis ...abi.ABI) (*RevertError, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var revertErr *RevertError
+	if errors.As(err, &revertErr) {
+		return revertErr, true
+	}
+
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := revertData(dataErr.ErrorData()); ok {
+			e := Decode(data, abis...)
+			e.cause = err
+			return e, true
+		}
+	}
+
+	if strings.Contains(err.Error(), "execution reverted") {
+		e := Decode(nil, abis...)
+		e.cause = err
+		return e, true
+	}
+
+	return nil, false
+}
+
+// Wrap returns a *RevertError when err is an execution revert and err
+// unchanged otherwise.
+func Wrap(err error, abis ...abi.ABI) error {
+	if e, ok := FromError(err, abis...); ok {
+		return e
+	}
+	return err
+}
+
+// revertData reads the revert payload from a JSON-RPC error data field. Nodes
+// return either a hex string or an object carrying one under "data".
+func revertData(data any) ([]byte, bool) {
+	switch v := data.(type) {
+	case string:
+		b, err := hexutil.Decode(v)
+		return b, err == nil
+	case map[string]any:
+		return revertData(v["data"])
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,229 @@
+package revert
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const testABI = `[
+	{"type":"error","name":"InsufficientBalance","inputs":[
+		{"name":"account","type":"address"},
+		{"name":"balance","type":"uint256"},
+		{"name":"","type":"uint256"}
+	]},
+	{"type":"error","name":"Unauthorized","inputs":[]}
+]`
+
+func parseABI(t *testing.T) abi.ABI {
+	t.Helper()
+
+	parsed, err := abi.JSON(strings.NewReader(testABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+// selector returns a copy of the selector of the custom error name.
+func selector(parsed abi.ABI, name string) []byte {
+	id := parsed.Errors[name].ID
+	return append([]byte{}, id[:4]...)
+}
+
+func packArgs(t *testing.T, selector []byte, types []string, values ...any) []byte {
+	t.Helper()
+
+	args := make(abi.Arguments, len(types))
+	for i, name := range types {
+		typ, err := abi.NewType(name, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		args[i] = abi.Argument{Type: typ}
+	}
+
+	packed, err := args.Pack(values...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(append([]byte{}, selector...), packed...)
+}
+
+func TestDecodeError(t *testing.T) {
+	data := packArgs(t, errorSelector, []string{"string"}, "insufficient allowance")
+
+	e := Decode(data)
+	if e.Kind != KindError || e.Reason != "insufficient allowance" {
+		t.Fatalf("got kind %d reason %q", e.Kind, e.Reason)
+	}
+	if e.Error() != "execution reverted: insufficient allowance" {
+		t.Errorf("Error() = %q", e.Error())
+	}
+
+	// A truncated Error(string) payload is left undecoded.
+	if e := Decode(data[:10]); e.Kind != KindUnknown {
+		t.Errorf("truncated reason: got kind %d, want KindUnknown", e.Kind)
+	}
+}
+
+func TestDecodePanic(t *testing.T) {
+	tests := []struct {
+		code   int64
+		reason string
+	}{
+		{0x01, "assertion failed"},
+		{0x11, "arithmetic underflow or overflow"},
+		{0x12, "division or modulo by zero"},
+		{0x32, "array index out of bounds"},
+		{0x99, "unknown panic code"},
+	}
+
+	for _, tt := range tests {
+		data := packArgs(t, panicSelector, []string{"uint256"}, big.NewInt(tt.code))
+
+		e := Decode(data)
+		if e.Kind != KindPanic {
+			t.Errorf("panic 0x%x: got kind %d, want KindPanic", tt.code, e.Kind)
+			continue
+		}
+		if e.PanicCode.Int64() != tt.code || e.PanicReason != tt.reason {
+			t.Errorf("panic 0x%x: got code %s reason %q, want %q", tt.code, e.PanicCode, e.PanicReason, tt.reason)
+		}
+		want := fmt.Sprintf("execution reverted: panic 0x%x (%s)", tt.code, tt.reason)
+		if e.Error() != want {
+			t.Errorf("Error() = %q, want %q", e.Error(), want)
+		}
+	}
+}
+
+func TestDecodeCustom(t *testing.T) {
+	parsed := parseABI(t)
+	account := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	data, err := parsed.Errors["InsufficientBalance"].Inputs.Pack(account, big.NewInt(5), big.NewInt(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = append(selector(parsed, "InsufficientBalance"), data...)
+
+	e := Decode(data, parsed)
+	if e.Kind != KindCustom {
+		t.Fatalf("got kind %d, want KindCustom", e.Kind)
+	}
+	if e.Name != "InsufficientBalance" || e.Signature != "InsufficientBalance(address,uint256,uint256)" {
+		t.Errorf("got name %q signature %q", e.Name, e.Signature)
+	}
+	if e.Args["account"] != account || e.Args["balance"].(*big.Int).Int64() != 5 || e.Args["arg2"].(*big.Int).Int64() != 10 {
+		t.Errorf("got args %v", e.Args)
+	}
+	if len(e.Values) != 3 {
+		t.Errorf("got %d values, want 3", len(e.Values))
+	}
+	want := fmt.Sprintf("execution reverted: InsufficientBalance(account=%s, balance=5, arg2=10)", account.Hex())
+	if e.Error() != want {
+		t.Errorf("Error() = %q, want %q", e.Error(), want)
+	}
+
+	unauthorized := Decode(selector(parsed, "Unauthorized"), parsed)
+	if unauthorized.Kind != KindCustom || unauthorized.Error() != "execution reverted: Unauthorized()" {
+		t.Errorf("Unauthorized: got kind %d, %q", unauthorized.Kind, unauthorized.Error())
+	}
+}
+
+func TestDecodeUnknown(t *testing.T) {
+	parsed := parseABI(t)
+
+	tests := []struct {
+		name string
+		data []byte
+		abis []abi.ABI
+		kind Kind
+	}{
+		{"empty", nil, nil, KindEmpty},
+		{"short", []byte{0x01, 0x02}, nil, KindUnknown},
+		{"unknown selector", []byte{0xde, 0xad, 0xbe, 0xef}, []abi.ABI{parsed}, KindUnknown},
+		{"custom error without its abi", selector(parsed, "Unauthorized"), nil, KindUnknown},
+		{"custom error with bad arguments", append(selector(parsed, "InsufficientBalance"), 0x01), []abi.ABI{parsed}, KindUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := Decode(tt.data, tt.abis...)
+			if e.Kind != tt.kind {
+				t.Fatalf("got kind %d, want %d", e.Kind, tt.kind)
+			}
+			if tt.kind == KindUnknown && len(tt.data) >= 4 && !strings.HasSuffix(e.Error(), hexutil.Encode(tt.data)) {
+				t.Errorf("Error() = %q does not include the data", e.Error())
+			}
+		})
+	}
+}
+
+// dataError is an rpc.DataError as returned by ethclient for reverts.
+type dataError struct {
+	data any
+}
+
+func (e *dataError) Error() string  { return "execution reverted" }
+func (e *dataError) ErrorData() any { return e.data }
+
+func TestFromError(t *testing.T) {
+	parsed := parseABI(t)
+	reason := packArgs(t, errorSelector, []string{"string"}, "paused")
+	custom := selector(parsed, "Unauthorized")
+
+	tests := []struct {
+		name string
+		err  error
+		ok   bool
+		kind Kind
+	}{
+		{"nil", nil, false, 0},
+		{"unrelated", errors.New("connection refused"), false, 0},
+		{"hex data", &dataError{data: hexutil.Encode(reason)}, true, KindError},
+		{"wrapped hex data", fmt.Errorf("estimate gas: %w", &dataError{data: hexutil.Encode(custom)}), true, KindCustom},
+		{"nested data object", &dataError{data: map[string]any{"data": hexutil.Encode(reason)}}, true, KindError},
+		{"message only", errors.New("execution reverted"), true, KindEmpty},
+		{"already decoded", fmt.Errorf("call: %w", Decode(reason)), true, KindError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, ok := FromError(tt.err, parsed)
+			if ok != tt.ok {
+				t.Fatalf("got ok %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if e.Kind != tt.kind {
+				t.Errorf("got kind %d, want %d", e.Kind, tt.kind)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	cause := &dataError{data: hexutil.Encode(packArgs(t, errorSelector, []string{"string"}, "paused"))}
+
+	err := Wrap(cause)
+	var e *RevertError
+	if !errors.As(err, &e) || e.Reason != "paused" {
+		t.Fatalf("got %v, want a RevertError with reason paused", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("wrapped error does not unwrap to its cause")
+	}
+
+	other := errors.New("timeout")
+	if Wrap(other) != other {
+		t.Error("Wrap changed an error that is not a revert")
+	}
+}
@@ -0,0 +1,176 @@
+package scanner
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/types"
+
+	"github.com/ethereum/go-ethereum"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultChunkSize   = 5000
+	defaultParallelism = 4
+)
+
+// rangeErrors are fragments of the messages providers return when an eth_getLogs
+// request covers too many blocks or matches too many logs.
+var rangeErrors = []string{
+	"query returned more than",
+	"block range",
+	"range is too large",
+	"range too large",
+	"response size exceeded",
+	"response size should not",
+	"too many results",
+	"too many blocks",
+	"limit exceeded",
+	"exceed maximum",
+}
+
+type impl struct {
+	provider    provider.Provider
+	chunkSize   uint64
+	parallelism int
+}
+
+func NewLogScanner(provider provider.Provider, config *Config) LogScanner {
+	s := &impl{
+		provider:    provider,
+		chunkSize:   defaultChunkSize,
+		parallelism: defaultParallelism,
+	}
+
+	if config != nil {
+		if config.ChunkSize > 0 {
+			s.chunkSize = config.ChunkSize
+		}
+		if config.Parallelism > 0 {
+			s.parallelism = config.Parallelism
+		}
+	}
+
+	return s
+}
+
+func (s *impl) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	if q.BlockHash != nil {
+		return s.provider.FilterLogs(ctx, q)
+	}
+
+	var from uint64
+	if q.FromBlock != nil {
+		from = q.FromBlock.Uint64()
+	}
+
+	var to uint64
+	if q.ToBlock != nil {
+		to = q.ToBlock.Uint64()
+	} else {
+		latest, err := s.provider.BlockNumber(ctx)
+		if err != nil {
+			return nil, err
+		}
+		to = latest
+	}
+
+	if from > to {
+		return nil, nil
+	}
+
+	var chunks [][2]uint64
+	for start := from; start <= to; start += s.chunkSize {
+		chunks = append(chunks, [2]uint64{start, min(start+s.chunkSize-1, to)})
+		if start+s.chunkSize < start {
+			break
+		}
+	}
+
+	results := make([][]types.Log, len(chunks))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(s.parallelism)
+
+	for idx, chunk := range chunks {
+		g.Go(func() error {
+			logs, err := s.scanRange(gctx, q, chunk[0], chunk[1])
+			if err != nil {
+				return err
+			}
+			results[idx] = logs
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return merge(results), nil
+}
+
+// scanRange fetches logs for [from, to], halving the range while the node rejects it.
+func (s *impl) scanRange(ctx context.Context, q ethereum.FilterQuery, from uint64, to uint64) ([]types.Log, error) {
+	chunkQuery := q
+	chunkQuery.FromBlock = new(big.Int).SetUint64(from)
+	chunkQuery.ToBlock = new(big.Int).SetUint64(to)
+
+	logs, err := s.provider.FilterLogs(ctx, chunkQuery)
+	if err == nil {
+		return logs, nil
+	}
+
+	if from == to || !isRangeError(err) {
+		return nil, fmt.Errorf("failed to filter logs in blocks %d-%d: %w", from, to, err)
+	}
+
+	mid := from + (to-from)/2
+
+	left, err := s.scanRange(ctx, q, from, mid)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := s.scanRange(ctx, q, mid+1, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(left, right...), nil
+}
+
+func merge(results [][]types.Log) []types.Log {
+	total := 0
+	for _, logs := range results {
+		total += len(logs)
+	}
+
+	h := make(types.LogHeap, 0, total)
+	for _, logs := range results {
+		h = append(h, logs...)
+	}
+	heap.Init(&h)
+
+	out := make([]types.Log, 0, total)
+	for h.Len() > 0 {
+		out = append(out, heap.Pop(&h).(types.Log))
+	}
+
+	return out
+}
+
+func isRangeError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, fragment := range rangeErrors {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
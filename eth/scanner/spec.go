@@ -0,0 +1,23 @@
+package scanner
+
+import (
+	"context"
+
+	"github.com/dtome123/go-bcwe3/eth/types"
+	"github.com/ethereum/go-ethereum"
+)
+
+type LogScanner interface {
+	// FilterLogs behaves like Provider.FilterLogs but splits the block range into
+	// chunks, halves chunks rejected by the node and returns logs ordered by
+	// (block number, log index). A nil FromBlock means genesis and a nil ToBlock
+	// means the latest block.
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+type Config struct {
+	// ChunkSize is the initial number of blocks per eth_getLogs request.
+	ChunkSize uint64
+	// Parallelism bounds the number of chunks requested concurrently.
+	Parallelism int
+}
@@ -0,0 +1,67 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	goethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	ErrInvalidHashLength = errors.New("hash must be 32 bytes")
+)
+
+type privateKeySigner struct {
+	key     *ecdsa.PrivateKey
+	address string
+}
+
+// NewPrivateKeySigner creates a Signer backed by a hex encoded private key.
+func NewPrivateKeySigner(privateKey string) (Signer, error) {
+	privateKey = strings.TrimSpace(privateKey)
+	if privateKey == "" {
+		return nil, errors.New("private key is required")
+	}
+
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %v", err)
+	}
+
+	return NewKeySigner(key), nil
+}
+
+// NewKeySigner creates a Signer from an already parsed private key.
+func NewKeySigner(key *ecdsa.PrivateKey) Signer {
+	return &privateKeySigner{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey).Hex(),
+	}
+}
+
+func (s *privateKeySigner) Address() string {
+	return s.address
+}
+
+func (s *privateKeySigner) SignTx(tx *goethTypes.Transaction, chainID *big.Int) (*goethTypes.Transaction, error) {
+	return goethTypes.SignTx(tx, goethTypes.LatestSignerForChainID(chainID), s.key)
+}
+
+func (s *privateKeySigner) SignHash(hash []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, ErrInvalidHashLength
+	}
+
+	sig, err := crypto.Sign(hash, s.key)
+	if err != nil {
+		return nil, err
+	}
+
+	sig[64] += 27
+
+	return sig, nil
+}
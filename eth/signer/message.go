@@ -0,0 +1,37 @@
+package signer
+
+import (
+	"github.com/dtome123/go-bcwe3/eth/verifier"
+
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// SignPersonal signs message the way personal_sign does (EIP-191 version 0x45).
+// The signature verifies with verifier.SignaturePersonalSign.
+func SignPersonal(s Signer, message string) ([]byte, error) {
+	return s.SignHash(verifier.HashPersonalMessage(message))
+}
+
+// SignTypedData signs typedData per EIP-712 (eth_signTypedData_v4). When
+// typedData has no EIP712Domain type it is derived from the domain fields
+// that are set. The signature verifies with verifier.SignatureTypedData.
+func SignTypedData(s Signer, typedData apitypes.TypedData) ([]byte, error) {
+	digest, err := verifier.HashTypedData(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.SignHash(digest)
+}
+
+// SignIntendedValidator signs data for the given validator contract
+// (EIP-191 version 0x00). The signature verifies with
+// verifier.SignatureIntendedValidator.
+func SignIntendedValidator(s Signer, validator string, data []byte) ([]byte, error) {
+	digest, err := verifier.HashIntendedValidator(validator, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.SignHash(digest)
+}
@@ -0,0 +1,16 @@
+package signer
+
+import (
+	"math/big"
+
+	goethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// Signer produces signatures on behalf of a single account.
+type Signer interface {
+	Address() string
+	SignTx(tx *goethTypes.Transaction, chainID *big.Int) (*goethTypes.Transaction, error)
+	// SignHash signs a 32-byte digest and returns a 65-byte [R || S || V]
+	// signature with V in {27, 28}.
+	SignHash(hash []byte) ([]byte, error)
+}
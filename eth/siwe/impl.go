@@ -0,0 +1,176 @@
+package siwe
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/verifier"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrMalformedMessage   = errors.New("malformed SIWE message")
+	ErrInvalidAddress     = errors.New("address must be EIP-55 checksummed")
+	ErrInvalidNonce       = errors.New("nonce must be at least 8 alphanumeric characters")
+	ErrUnsupportedVersion = errors.New("unsupported SIWE version")
+	ErrDomainMismatch     = errors.New("domain mismatch")
+	ErrURIMismatch        = errors.New("uri mismatch")
+	ErrChainIDMismatch    = errors.New("chain id mismatch")
+	ErrIssuedInFuture     = errors.New("message issued in the future")
+	ErrExpired            = errors.New("message expired")
+	ErrNotYetValid        = errors.New("message not yet valid")
+	ErrNonceNotFound      = errors.New("nonce not found or already used")
+	ErrMissingDomain      = errors.New("config domain is required")
+	ErrMissingOptions     = errors.New("message options are required")
+)
+
+const (
+	version         = "1"
+	defaultNonceTTL = 10 * time.Minute
+)
+
+type impl struct {
+	verifier verifier.Verifier
+	store    NonceStore
+	config   Config
+}
+
+// New creates a SIWE service; config.Domain is required. With a nil provider
+// signatures are checked by ecrecover only; with a provider EIP-1271 and
+// ERC-6492 wallets are supported. A nil store defaults to a memory store
+// driven by config.Now.
+func New(provider provider.Provider, store NonceStore, config *Config) (SIWE, error) {
+	if config == nil || config.Domain == "" {
+		return nil, ErrMissingDomain
+	}
+
+	cfg := *config
+	if cfg.NonceTTL <= 0 {
+		cfg.NonceTTL = defaultNonceTTL
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	if store == nil {
+		store = NewMemoryNonceStore(cfg.Now)
+	}
+
+	s := &impl{
+		store:  store,
+		config: cfg,
+	}
+	if provider != nil {
+		s.verifier = verifier.NewVerifier(provider)
+	}
+
+	return s, nil
+}
+
+func (s *impl) GenerateNonce(ctx context.Context) (string, error) {
+	nonce, err := GenerateNonce()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.store.Issue(ctx, nonce, s.config.Now().Add(s.config.NonceTTL)); err != nil {
+		return "", err
+	}
+
+	return nonce, nil
+}
+
+func (s *impl) NewMessage(ctx context.Context, opts *MessageOptions) (*Message, error) {
+	if opts == nil {
+		return nil, ErrMissingOptions
+	}
+	if !common.IsHexAddress(opts.Address) {
+		return nil, ErrInvalidAddress
+	}
+
+	nonce, err := s.GenerateNonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		Domain:         s.config.Domain,
+		Address:        common.HexToAddress(opts.Address).Hex(),
+		Statement:      opts.Statement,
+		URI:            s.config.URI,
+		Version:        version,
+		ChainID:        s.config.ChainID,
+		Nonce:          nonce,
+		IssuedAt:       s.config.Now().UTC().Truncate(time.Second),
+		ExpirationTime: opts.ExpirationTime,
+		NotBefore:      opts.NotBefore,
+		RequestID:      opts.RequestID,
+		Resources:      opts.Resources,
+	}, nil
+}
+
+func (s *impl) Verify(ctx context.Context, message string, signature string) (*Message, error) {
+	m, err := Parse(message)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validate(m); err != nil {
+		return nil, err
+	}
+
+	req := &verifier.VerifyRequest{
+		SignatureType: verifier.SignaturePersonalSign,
+		Payload:       message,
+		Signature:     signature,
+		ExpectedAddr:  m.Address,
+	}
+
+	if s.verifier != nil {
+		err = s.verifier.Verify(ctx, req)
+	} else {
+		err = verifier.Verify(req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The nonce is consumed only once the signature checks out, so a forged
+	// message cannot burn a legitimate user's nonce.
+	if err := s.store.Consume(ctx, m.Nonce); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (s *impl) validate(m *Message) error {
+	if m.Version != version {
+		return ErrUnsupportedVersion
+	}
+	if m.Domain != s.config.Domain {
+		return ErrDomainMismatch
+	}
+	if s.config.URI != "" && m.URI != s.config.URI {
+		return ErrURIMismatch
+	}
+	if s.config.ChainID != 0 && m.ChainID != s.config.ChainID {
+		return ErrChainIDMismatch
+	}
+
+	now := s.config.Now()
+	skew := s.config.ClockSkew
+
+	if m.IssuedAt.After(now.Add(skew)) {
+		return ErrIssuedInFuture
+	}
+	if m.ExpirationTime != nil && !now.Add(-skew).Before(*m.ExpirationTime) {
+		return ErrExpired
+	}
+	if m.NotBefore != nil && now.Add(skew).Before(*m.NotBefore) {
+		return ErrNotYetValid
+	}
+
+	return nil
+}
@@ -0,0 +1,241 @@
+package siwe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	headerSuffix = " wants you to sign in with your Ethereum account:"
+
+	uriTag            = "URI: "
+	versionTag        = "Version: "
+	chainIDTag        = "Chain ID: "
+	nonceTag          = "Nonce: "
+	issuedAtTag       = "Issued At: "
+	expirationTimeTag = "Expiration Time: "
+	notBeforeTag      = "Not Before: "
+	requestIDTag      = "Request ID: "
+	resourcesTag      = "Resources:"
+	resourcePrefix    = "- "
+)
+
+// String renders the message in the EIP-4361 text format.
+func (m *Message) String() string {
+	var b strings.Builder
+
+	if m.Scheme != "" {
+		b.WriteString(m.Scheme + "://")
+	}
+	b.WriteString(m.Domain + headerSuffix + "\n")
+	b.WriteString(m.Address + "\n\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n\n")
+	}
+
+	b.WriteString(uriTag + m.URI + "\n")
+	b.WriteString(versionTag + m.Version + "\n")
+	b.WriteString(chainIDTag + strconv.FormatInt(m.ChainID, 10) + "\n")
+	b.WriteString(nonceTag + m.Nonce + "\n")
+	b.WriteString(issuedAtTag + formatTime(m.IssuedAt))
+
+	if m.ExpirationTime != nil {
+		b.WriteString("\n" + expirationTimeTag + formatTime(*m.ExpirationTime))
+	}
+	if m.NotBefore != nil {
+		b.WriteString("\n" + notBeforeTag + formatTime(*m.NotBefore))
+	}
+	if m.RequestID != "" {
+		b.WriteString("\n" + requestIDTag + m.RequestID)
+	}
+	if len(m.Resources) > 0 {
+		b.WriteString("\n" + resourcesTag)
+		for _, resource := range m.Resources {
+			b.WriteString("\n" + resourcePrefix + resource)
+		}
+	}
+
+	return b.String()
+}
+
+// Parse parses an EIP-4361 message. A single trailing newline, as left by
+// editors and text areas, is ignored.
+func Parse(message string) (*Message, error) {
+	message = strings.TrimSuffix(strings.ReplaceAll(message, "\r\n", "\n"), "\n")
+	lines := strings.Split(message, "\n")
+	p := &parser{lines: lines}
+	m := &Message{}
+
+	header, ok := p.next()
+	if !ok || !strings.HasSuffix(header, headerSuffix) {
+		return nil, malformed("header")
+	}
+	m.Domain = strings.TrimSuffix(header, headerSuffix)
+	if scheme, domain, found := strings.Cut(m.Domain, "://"); found {
+		m.Scheme, m.Domain = scheme, domain
+	}
+	if m.Domain == "" {
+		return nil, malformed("domain")
+	}
+
+	address, ok := p.next()
+	if !ok || !common.IsHexAddress(address) {
+		return nil, ErrInvalidAddress
+	}
+	if common.HexToAddress(address).Hex() != address {
+		return nil, ErrInvalidAddress
+	}
+	m.Address = address
+
+	if line, ok := p.next(); !ok || line != "" {
+		return nil, malformed("address")
+	}
+
+	// The statement is optional; older encoders leave an extra blank line
+	// when it is absent.
+	if line, ok := p.peek(); ok && !strings.HasPrefix(line, uriTag) {
+		p.next()
+		if line != "" {
+			m.Statement = line
+			if blank, ok := p.next(); !ok || blank != "" {
+				return nil, malformed("statement")
+			}
+		}
+	}
+
+	var err error
+
+	if m.URI, err = p.required(uriTag); err != nil {
+		return nil, err
+	}
+	if m.Version, err = p.required(versionTag); err != nil {
+		return nil, err
+	}
+
+	chainID, err := p.required(chainIDTag)
+	if err != nil {
+		return nil, err
+	}
+	if m.ChainID, err = strconv.ParseInt(chainID, 10, 64); err != nil {
+		return nil, malformed("chain id")
+	}
+
+	if m.Nonce, err = p.required(nonceTag); err != nil {
+		return nil, err
+	}
+	if !isValidNonce(m.Nonce) {
+		return nil, ErrInvalidNonce
+	}
+
+	issuedAt, err := p.required(issuedAtTag)
+	if err != nil {
+		return nil, err
+	}
+	if m.IssuedAt, err = parseTime(issuedAt); err != nil {
+		return nil, malformed("issued at")
+	}
+
+	if value, ok := p.optional(expirationTimeTag); ok {
+		t, err := parseTime(value)
+		if err != nil {
+			return nil, malformed("expiration time")
+		}
+		m.ExpirationTime = &t
+	}
+
+	if value, ok := p.optional(notBeforeTag); ok {
+		t, err := parseTime(value)
+		if err != nil {
+			return nil, malformed("not before")
+		}
+		m.NotBefore = &t
+	}
+
+	if value, ok := p.optional(requestIDTag); ok {
+		m.RequestID = value
+	}
+
+	if line, ok := p.peek(); ok && line == resourcesTag {
+		p.next()
+		for {
+			line, ok := p.peek()
+			if !ok || !strings.HasPrefix(line, resourcePrefix) {
+				break
+			}
+			p.next()
+			m.Resources = append(m.Resources, strings.TrimPrefix(line, resourcePrefix))
+		}
+	}
+
+	if line, ok := p.next(); ok {
+		return nil, malformed(fmt.Sprintf("unexpected line %q", line))
+	}
+
+	return m, nil
+}
+
+type parser struct {
+	lines []string
+	pos   int
+}
+
+func (p *parser) peek() (string, bool) {
+	if p.pos >= len(p.lines) {
+		return "", false
+	}
+	return p.lines[p.pos], true
+}
+
+func (p *parser) next() (string, bool) {
+	line, ok := p.peek()
+	if ok {
+		p.pos++
+	}
+	return line, ok
+}
+
+func (p *parser) required(tag string) (string, error) {
+	value, ok := p.optional(tag)
+	if !ok {
+		return "", malformed(strings.TrimSuffix(tag, ": "))
+	}
+	return value, nil
+}
+
+func (p *parser) optional(tag string) (string, bool) {
+	line, ok := p.peek()
+	if !ok || !strings.HasPrefix(line, tag) {
+		return "", false
+	}
+	p.next()
+	return strings.TrimPrefix(line, tag), true
+}
+
+func malformed(field string) error {
+	return fmt.Errorf("%w: %s", ErrMalformedMessage, field)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func parseTime(value string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+// isValidNonce reports whether nonce is at least 8 alphanumeric characters.
+func isValidNonce(nonce string) bool {
+	if len(nonce) < 8 {
+		return false
+	}
+	for _, c := range nonce {
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
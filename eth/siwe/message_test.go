@@ -0,0 +1,170 @@
+package siwe
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testAddress = "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"
+
+func testMessage() *Message {
+	issuedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	expiration := issuedAt.Add(time.Hour)
+	notBefore := issuedAt.Add(time.Minute)
+
+	return &Message{
+		Scheme:         "https",
+		Domain:         "example.com",
+		Address:        testAddress,
+		Statement:      "Sign in to Example.",
+		URI:            "https://example.com/login",
+		Version:        "1",
+		ChainID:        1,
+		Nonce:          "abcdEFGH1234",
+		IssuedAt:       issuedAt,
+		ExpirationTime: &expiration,
+		NotBefore:      &notBefore,
+		RequestID:      "req-1",
+		Resources:      []string{"ipfs://resource", "https://example.com/terms"},
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	full := testMessage()
+
+	minimal := testMessage()
+	minimal.Scheme = ""
+	minimal.Statement = ""
+	minimal.ExpirationTime = nil
+	minimal.NotBefore = nil
+	minimal.RequestID = ""
+	minimal.Resources = nil
+
+	withoutStatement := testMessage()
+	withoutStatement.Statement = ""
+
+	tests := []struct {
+		name    string
+		message *Message
+	}{
+		{"full", full},
+		{"minimal", minimal},
+		{"without statement", withoutStatement},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.message.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.message) {
+				t.Errorf("got %+v, want %+v", got, tt.message)
+			}
+			if got.String() != tt.message.String() {
+				t.Errorf("String() changed after Parse:\n%s\nwant\n%s", got, tt.message)
+			}
+		})
+	}
+}
+
+func TestParseWithoutStatementExtraBlankLine(t *testing.T) {
+	want := testMessage()
+	want.Statement = ""
+
+	// Older encoders leave a second blank line when the statement is absent.
+	text := strings.Replace(want.String(), testAddress+"\n\n", testAddress+"\n\n\n", 1)
+
+	got, err := Parse(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestParseTrailingNewline(t *testing.T) {
+	want := testMessage()
+
+	for _, suffix := range []string{"\n", "\r\n"} {
+		got, err := Parse(want.String() + suffix)
+		if err != nil {
+			t.Fatalf("suffix %q: %v", suffix, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("suffix %q: got %+v, want %+v", suffix, got, want)
+		}
+	}
+
+	if _, err := Parse(want.String() + "\n\n"); !errors.Is(err, ErrMalformedMessage) {
+		t.Errorf("two trailing newlines: got %v, want ErrMalformedMessage", err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	valid := testMessage().String()
+
+	tests := []struct {
+		name    string
+		message string
+		err     error
+	}{
+		{
+			name:    "non-checksummed address",
+			message: strings.Replace(valid, testAddress, strings.ToLower(testAddress), 1),
+			err:     ErrInvalidAddress,
+		},
+		{
+			name:    "invalid address",
+			message: strings.Replace(valid, testAddress, "0x1234", 1),
+			err:     ErrInvalidAddress,
+		},
+		{
+			name:    "short nonce",
+			message: strings.Replace(valid, "Nonce: abcdEFGH1234", "Nonce: abc123", 1),
+			err:     ErrInvalidNonce,
+		},
+		{
+			name:    "non-alphanumeric nonce",
+			message: strings.Replace(valid, "Nonce: abcdEFGH1234", "Nonce: abcd-EFGH-1234", 1),
+			err:     ErrInvalidNonce,
+		},
+		{
+			name:    "missing header",
+			message: strings.Replace(valid, headerSuffix, "", 1),
+			err:     ErrMalformedMessage,
+		},
+		{
+			name:    "missing uri",
+			message: strings.Replace(valid, "URI: https://example.com/login\n", "", 1),
+			err:     ErrMalformedMessage,
+		},
+		{
+			name:    "invalid chain id",
+			message: strings.Replace(valid, "Chain ID: 1", "Chain ID: one", 1),
+			err:     ErrMalformedMessage,
+		},
+		{
+			name:    "invalid issued at",
+			message: strings.Replace(valid, "Issued At: 2024-05-01T12:00:00Z", "Issued At: yesterday", 1),
+			err:     ErrMalformedMessage,
+		},
+		{
+			name:    "unexpected line",
+			message: valid + "\nExtra: value",
+			err:     ErrMalformedMessage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.message); !errors.Is(err, tt.err) {
+				t.Errorf("got %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
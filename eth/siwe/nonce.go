@@ -0,0 +1,82 @@
+package siwe
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"sync"
+	"time"
+)
+
+const (
+	nonceAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	nonceLength   = 17
+)
+
+// GenerateNonce returns a random alphanumeric nonce suitable for EIP-4361.
+func GenerateNonce() (string, error) {
+	max := big.NewInt(int64(len(nonceAlphabet)))
+	nonce := make([]byte, nonceLength)
+
+	for i := range nonce {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		nonce[i] = nonceAlphabet[n.Int64()]
+	}
+
+	return string(nonce), nil
+}
+
+type memoryNonceStore struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time
+	now    func() time.Time
+}
+
+// NewMemoryNonceStore returns a process-local NonceStore whose expiry follows
+// now, or time.Now when nil. Use a shared store (e.g. Redis or a database)
+// when running several instances.
+func NewMemoryNonceStore(now func() time.Time) NonceStore {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &memoryNonceStore{
+		nonces: make(map[string]time.Time),
+		now:    now,
+	}
+}
+
+func (s *memoryNonceStore) Issue(ctx context.Context, nonce string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for n, exp := range s.nonces {
+		if !now.Before(exp) {
+			delete(s.nonces, n)
+		}
+	}
+
+	s.nonces[nonce] = expiresAt
+	return nil
+}
+
+func (s *memoryNonceStore) Consume(ctx context.Context, nonce string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.nonces[nonce]
+	if !ok {
+		return ErrNonceNotFound
+	}
+	delete(s.nonces, nonce)
+
+	if !s.now().Before(expiresAt) {
+		return ErrNonceNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package siwe
+
+import (
+	"context"
+	"time"
+)
+
+// SIWE issues and verifies Sign-In with Ethereum (EIP-4361) messages.
+type SIWE interface {
+	// GenerateNonce returns a fresh nonce and records it in the nonce store.
+	GenerateNonce(ctx context.Context) (string, error)
+	// NewMessage builds a message for the configured domain, URI and chain
+	// with a freshly generated nonce.
+	NewMessage(ctx context.Context, opts *MessageOptions) (*Message, error)
+	// Verify parses message, validates it against the configuration and the
+	// current time, verifies signature and consumes the nonce.
+	Verify(ctx context.Context, message string, signature string) (*Message, error)
+}
+
+// NonceStore tracks outstanding nonces so that a signed message can only be
+// used once.
+type NonceStore interface {
+	// Issue records nonce as outstanding until expiresAt.
+	Issue(ctx context.Context, nonce string, expiresAt time.Time) error
+	// Consume marks nonce as used. It returns ErrNonceNotFound when the nonce
+	// was never issued, already consumed or has expired.
+	Consume(ctx context.Context, nonce string) error
+}
+
+type Config struct {
+	// Domain is the RFC 3986 authority expected in messages, e.g. "example.com". Required.
+	Domain string
+	// URI is the expected resource URI. Empty skips the check.
+	URI string
+	// ChainID is the expected chain id. Zero skips the check.
+	ChainID int64
+	// NonceTTL bounds how long an issued nonce stays valid. Defaults to 10 minutes.
+	NonceTTL time.Duration
+	// ClockSkew is tolerated when checking issued-at, expiration and not-before.
+	ClockSkew time.Duration
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+type MessageOptions struct {
+	Address        string
+	Statement      string
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// Message is an EIP-4361 message.
+type Message struct {
+	Scheme         string     `json:"scheme,omitempty"`
+	Domain         string     `json:"domain"`
+	Address        string     `json:"address"`
+	Statement      string     `json:"statement,omitempty"`
+	URI            string     `json:"uri"`
+	Version        string     `json:"version"`
+	ChainID        int64      `json:"chainId"`
+	Nonce          string     `json:"nonce"`
+	IssuedAt       time.Time  `json:"issuedAt"`
+	ExpirationTime *time.Time `json:"expirationTime,omitempty"`
+	NotBefore      *time.Time `json:"notBefore,omitempty"`
+	RequestID      string     `json:"requestId,omitempty"`
+	Resources      []string   `json:"resources,omitempty"`
+}
@@ -1,11 +1,18 @@
 package eth
 
 import (
+	"context"
+
 	"github.com/dtome123/go-bcwe3/eth/contract"
+	"github.com/dtome123/go-bcwe3/eth/deployer"
+	"github.com/dtome123/go-bcwe3/eth/detector"
 	"github.com/dtome123/go-bcwe3/eth/erc1155"
 	"github.com/dtome123/go-bcwe3/eth/erc20"
+	"github.com/dtome123/go-bcwe3/eth/erc4626"
 	"github.com/dtome123/go-bcwe3/eth/erc721"
 	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/dtome123/go-bcwe3/eth/signer"
+	"github.com/dtome123/go-bcwe3/eth/verifier"
 )
 
 type Eth interface {
@@ -14,5 +21,10 @@ type Eth interface {
 	NewERC721(address string) (erc721.ERC721, error)
 	NewERC1155(address string) (erc1155.ERC1155, error)
 	NewERC20(address string) (erc20.ERC20, error)
+	NewERC4626(address string) (erc4626.ERC4626, error)
 	GetProvider() provider.Provider
+	DetectStandard(ctx context.Context, address string) (*detector.Detection, error)
+	VerifySignature(ctx context.Context, req *verifier.VerifyRequest) error
+	DeployContract(ctx context.Context, signer signer.Signer, abiData string, bytecode string, args ...any) (string, contract.Contract, error)
+	NewDeployer(config *deployer.Config) deployer.Deployer
 }
@@ -2,9 +2,16 @@ package types
 
 type LogHeap []Log
 
-func (h LogHeap) Len() int           { return len(h) }
-func (h LogHeap) Less(i, j int) bool { return h[i].BlockNumber < h[j].BlockNumber }
-func (h LogHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h LogHeap) Len() int      { return len(h) }
+func (h LogHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+// Less orders logs by (block number, log index).
+func (h LogHeap) Less(i, j int) bool {
+	if h[i].BlockNumber != h[j].BlockNumber {
+		return h[i].BlockNumber < h[j].BlockNumber
+	}
+	return h[i].Index < h[j].Index
+}
 
 func (h *LogHeap) Push(x any) {
 	xLog, ok := x.(Log)
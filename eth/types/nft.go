@@ -25,3 +25,16 @@ type NFTBalance struct {
 	Balance *big.Int `json:"balance"`
 	Owner   string   `json:"owner"`
 }
+
+type NFTRoyalty struct {
+	TokenId   string   `json:"token_id"`
+	SalePrice *big.Int `json:"sale_price"`
+	Receiver  string   `json:"receiver"`
+	Amount    *big.Int `json:"amount"`
+}
+
+type NFTRental struct {
+	TokenId string `json:"token_id"`
+	User    string `json:"user"`
+	Expires uint64 `json:"expires"`
+}
@@ -10,6 +10,14 @@ type ERC20Token struct {
 	TotalSupply *big.Int `json:"total_supply"`
 }
 
+// ERC20Snapshot is token info and balances read at one block.
+type ERC20Snapshot struct {
+	Token       *ERC20Token         `json:"token"`
+	BlockNumber *big.Int            `json:"block_number"`
+	BlockHash   string              `json:"block_hash"`
+	Balances    map[string]*big.Int `json:"balances"`
+}
+
 type ERC20Balance struct {
 	Token   ERC20Token
 	Balance *big.Int
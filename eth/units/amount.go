@@ -0,0 +1,84 @@
+package units
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/dtome123/go-bcwe3/eth/types"
+)
+
+// TokenAmount is a raw on-chain amount paired with the token it is denominated in.
+type TokenAmount struct {
+	Amount *big.Int
+	Token  types.ERC20Token
+}
+
+type tokenAmountJSON struct {
+	Token     types.ERC20Token `json:"token"`
+	Raw       string           `json:"raw"`
+	Formatted string           `json:"formatted"`
+}
+
+func NewTokenAmount(amount *big.Int, token types.ERC20Token) *TokenAmount {
+	return &TokenAmount{
+		Amount: amount,
+		Token:  token,
+	}
+}
+
+// ParseTokenAmount parses a human readable value like "1.5" using the token decimals.
+func ParseTokenAmount(value string, token types.ERC20Token) (*TokenAmount, error) {
+	amount, err := Parse(value, token.Decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTokenAmount(amount, token), nil
+}
+
+func (a TokenAmount) Formatted() string {
+	return Format(a.Amount, a.Token.Decimals)
+}
+
+func (a TokenAmount) FormatWithPrecision(precision int, mode RoundingMode) (string, error) {
+	return FormatWithPrecision(a.Amount, a.Token.Decimals, precision, mode)
+}
+
+func (a TokenAmount) String() string {
+	if a.Token.Symbol == "" {
+		return a.Formatted()
+	}
+	return fmt.Sprintf("%s %s", a.Formatted(), a.Token.Symbol)
+}
+
+func (a TokenAmount) MarshalJSON() ([]byte, error) {
+	raw := "0"
+	if a.Amount != nil {
+		raw = a.Amount.String()
+	}
+
+	return json.Marshal(tokenAmountJSON{
+		Token:     a.Token,
+		Raw:       raw,
+		Formatted: a.Formatted(),
+	})
+}
+
+// UnmarshalJSON restores the amount from the raw value; the formatted value is informational only.
+func (a *TokenAmount) UnmarshalJSON(data []byte) error {
+	var v tokenAmountJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	amount, ok := new(big.Int).SetString(v.Raw, 10)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrInvalidAmount, v.Raw)
+	}
+
+	a.Amount = amount
+	a.Token = v.Token
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package units
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrInvalidAmount     = errors.New("invalid decimal amount")
+	ErrTooManyDecimals   = errors.New("amount has more fractional digits than the token decimals")
+	ErrNegativePrecision = errors.New("precision cannot be negative")
+)
+
+const (
+	WeiDecimals   uint8 = 0
+	GweiDecimals  uint8 = 9
+	EtherDecimals uint8 = 18
+)
+
+// RoundingMode controls how amounts are rounded when formatted with fewer
+// fractional digits than the token decimals.
+type RoundingMode int
+
+const (
+	// RoundDown truncates toward zero.
+	RoundDown RoundingMode = iota
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundHalfUp rounds to the nearest value, ties away from zero.
+	RoundHalfUp
+	// RoundHalfEven rounds to the nearest value, ties to the even neighbour.
+	RoundHalfEven
+)
+
+// Parse converts a human readable decimal string such as "1.5" into base units
+// for a token with the given decimals. The conversion is exact: values with more
+// fractional digits than decimals are rejected instead of silently rounded.
+func Parse(value string, decimals uint8) (*big.Int, error) {
+	value = strings.TrimSpace(value)
+
+	negative := false
+	switch {
+	case strings.HasPrefix(value, "-"):
+		negative = true
+		value = value[1:]
+	case strings.HasPrefix(value, "+"):
+		value = value[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" && fraction == "" {
+		return nil, ErrInvalidAmount
+	}
+	if !isDigits(whole) || !isDigits(fraction) {
+		return nil, ErrInvalidAmount
+	}
+
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > int(decimals) {
+		return nil, ErrTooManyDecimals
+	}
+
+	digits := whole + fraction + strings.Repeat("0", int(decimals)-len(fraction))
+	if digits == "" {
+		digits = "0"
+	}
+
+	amount, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, ErrInvalidAmount
+	}
+
+	if negative {
+		amount.Neg(amount)
+	}
+
+	return amount, nil
+}
+
+// Format renders base units as a decimal string with full precision and
+// trailing zeros removed, e.g. 1500000 with 6 decimals becomes "1.5".
+func Format(amount *big.Int, decimals uint8) string {
+	if amount == nil {
+		return "0"
+	}
+
+	whole, fraction := split(new(big.Int).Abs(amount), int(decimals))
+	fraction = strings.TrimRight(fraction, "0")
+
+	out := whole
+	if fraction != "" {
+		out += "." + fraction
+	}
+
+	if amount.Sign() < 0 {
+		return "-" + out
+	}
+
+	return out
+}
+
+// FormatWithPrecision renders base units with exactly precision fractional
+// digits, rounding with mode when precision is lower than decimals.
+func FormatWithPrecision(amount *big.Int, decimals uint8, precision int, mode RoundingMode) (string, error) {
+	if precision < 0 {
+		return "", ErrNegativePrecision
+	}
+	if amount == nil {
+		amount = new(big.Int)
+	}
+
+	scaled := new(big.Int).Set(amount)
+	if precision < int(decimals) {
+		divisor := pow10(int(decimals) - precision)
+		scaled = roundQuo(scaled, divisor, mode)
+	} else {
+		scaled.Mul(scaled, pow10(precision-int(decimals)))
+	}
+
+	whole, fraction := split(new(big.Int).Abs(scaled), precision)
+
+	out := whole
+	if precision > 0 {
+		out += "." + fraction
+	}
+
+	if scaled.Sign() < 0 {
+		return "-" + out, nil
+	}
+
+	return out, nil
+}
+
+func ParseEther(value string) (*big.Int, error) {
+	return Parse(value, EtherDecimals)
+}
+
+func ParseGwei(value string) (*big.Int, error) {
+	return Parse(value, GweiDecimals)
+}
+
+func FormatEther(wei *big.Int) string {
+	return Format(wei, EtherDecimals)
+}
+
+func FormatGwei(wei *big.Int) string {
+	return Format(wei, GweiDecimals)
+}
+
+// GweiToWei converts a whole number of gwei to wei.
+func GweiToWei(gwei uint64) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(gwei), pow10(int(GweiDecimals)))
+}
+
+// EtherToWei converts a whole number of ether to wei.
+func EtherToWei(ether uint64) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(ether), pow10(int(EtherDecimals)))
+}
+
+// split returns the integer and zero padded fractional digits of a non-negative amount.
+func split(amount *big.Int, decimals int) (string, string) {
+	digits := amount.String()
+	if decimals == 0 {
+		return digits, ""
+	}
+
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	return digits[:len(digits)-decimals], digits[len(digits)-decimals:]
+}
+
+func roundQuo(n *big.Int, d *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	away := false
+	switch mode {
+	case RoundUp:
+		away = true
+	case RoundHalfUp, RoundHalfEven:
+		cmp := new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(d)
+		away = cmp > 0 || (cmp == 0 && (mode == RoundHalfUp || q.Bit(0) == 1))
+	}
+
+	if !away {
+		return q
+	}
+
+	if n.Sign() < 0 {
+		return q.Sub(q, big.NewInt(1))
+	}
+
+	return q.Add(q, big.NewInt(1))
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
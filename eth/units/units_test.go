@@ -0,0 +1,128 @@
+package units
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value    string
+		decimals uint8
+		want     string
+		err      error
+	}{
+		{value: "1.5", decimals: 6, want: "1500000"},
+		{value: "0", decimals: 18, want: "0"},
+		{value: "42", decimals: 0, want: "42"},
+		{value: "-1.5", decimals: 6, want: "-1500000"},
+		{value: "+1.5", decimals: 6, want: "1500000"},
+		{value: "-0", decimals: 6, want: "0"},
+		{value: ".5", decimals: 6, want: "500000"},
+		{value: "1.", decimals: 6, want: "1000000"},
+		{value: " 2.25 ", decimals: 2, want: "225"},
+		{value: "1.500000000", decimals: 6, want: "1500000"},
+		{value: "0.000001", decimals: 6, want: "1"},
+		{value: "0.0000001", decimals: 6, err: ErrTooManyDecimals},
+		{value: "1.5", decimals: 0, err: ErrTooManyDecimals},
+		{value: "", decimals: 6, err: ErrInvalidAmount},
+		{value: ".", decimals: 6, err: ErrInvalidAmount},
+		{value: "-", decimals: 6, err: ErrInvalidAmount},
+		{value: "--1", decimals: 6, err: ErrInvalidAmount},
+		{value: "1.2.3", decimals: 6, err: ErrInvalidAmount},
+		{value: "1e18", decimals: 18, err: ErrInvalidAmount},
+		{value: "0x10", decimals: 6, err: ErrInvalidAmount},
+		{value: "1 000", decimals: 6, err: ErrInvalidAmount},
+		{value: "abc", decimals: 6, err: ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.value, tt.decimals)
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Parse(%q, %d) error = %v, want %v", tt.value, tt.decimals, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q, %d) unexpected error: %v", tt.value, tt.decimals, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("Parse(%q, %d) = %s, want %s", tt.value, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func TestFormatWithPrecision(t *testing.T) {
+	tests := []struct {
+		amount    string
+		decimals  uint8
+		precision int
+		mode      RoundingMode
+		want      string
+	}{
+		// 1.2345 with 4 decimals rounded to 2 digits.
+		{"12345", 4, 2, RoundDown, "1.23"},
+		{"12345", 4, 2, RoundUp, "1.24"},
+		{"12345", 4, 2, RoundHalfUp, "1.23"},
+		{"12345", 4, 2, RoundHalfEven, "1.23"},
+		{"-12345", 4, 2, RoundDown, "-1.23"},
+		{"-12345", 4, 2, RoundUp, "-1.24"},
+		{"-12345", 4, 2, RoundHalfUp, "-1.23"},
+		{"-12345", 4, 2, RoundHalfEven, "-1.23"},
+
+		// Ties: 1.25 and 1.35 rounded to 1 digit.
+		{"125", 2, 1, RoundDown, "1.2"},
+		{"125", 2, 1, RoundUp, "1.3"},
+		{"125", 2, 1, RoundHalfUp, "1.3"},
+		{"125", 2, 1, RoundHalfEven, "1.2"},
+		{"135", 2, 1, RoundHalfEven, "1.4"},
+		{"-125", 2, 1, RoundDown, "-1.2"},
+		{"-125", 2, 1, RoundUp, "-1.3"},
+		{"-125", 2, 1, RoundHalfUp, "-1.3"},
+		{"-125", 2, 1, RoundHalfEven, "-1.2"},
+		{"-135", 2, 1, RoundHalfEven, "-1.4"},
+
+		// Exact values are not changed by any mode.
+		{"120", 2, 1, RoundUp, "1.2"},
+		{"-120", 2, 1, RoundUp, "-1.2"},
+
+		// Rounding into the whole part and to zero.
+		{"999", 3, 2, RoundUp, "1.00"},
+		{"999", 3, 0, RoundHalfUp, "1"},
+		{"1", 3, 0, RoundDown, "0"},
+		{"-1", 3, 0, RoundDown, "0"},
+		{"-1", 3, 0, RoundUp, "-1"},
+
+		// Padding when precision exceeds decimals.
+		{"15", 1, 3, RoundDown, "1.500"},
+		{"-15", 1, 3, RoundDown, "-1.500"},
+		{"7", 0, 0, RoundDown, "7"},
+	}
+
+	for _, tt := range tests {
+		amount, _ := new(big.Int).SetString(tt.amount, 10)
+
+		got, err := FormatWithPrecision(amount, tt.decimals, tt.precision, tt.mode)
+		if err != nil {
+			t.Errorf("FormatWithPrecision(%s, %d, %d, %d) unexpected error: %v", tt.amount, tt.decimals, tt.precision, tt.mode, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("FormatWithPrecision(%s, %d, %d, %d) = %q, want %q", tt.amount, tt.decimals, tt.precision, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestFormatWithPrecisionErrors(t *testing.T) {
+	if _, err := FormatWithPrecision(big.NewInt(1), 2, -1, RoundDown); !errors.Is(err, ErrNegativePrecision) {
+		t.Errorf("negative precision: got %v, want ErrNegativePrecision", err)
+	}
+
+	got, err := FormatWithPrecision(nil, 2, 2, RoundDown)
+	if err != nil || got != "0.00" {
+		t.Errorf("nil amount: got %q, %v, want \"0.00\"", got, err)
+	}
+}
@@ -0,0 +1,75 @@
+package utils
+
+const (
+	opLT     = 0x10
+	opGT     = 0x11
+	opEQ     = 0x14
+	opPUSH1  = 0x60
+	opPUSH4  = 0x63
+	opPUSH32 = 0x7f
+	opDUP1   = 0x80
+	opSWAP16 = 0x9f
+
+	opDELEGATECALL = 0xf4
+)
+
+// ExtractSelectors walks EVM bytecode instruction by instruction and returns the
+// 4-byte function selectors the dispatcher compares calldata against. PUSH
+// immediates are skipped, so selector-like bytes inside other constants are not
+// reported, and values used as binary search pivots (followed by GT/LT) are
+// ignored. solc pushes selectors with leading zero bytes using PUSH1-PUSH3, so
+// those are left-padded to four bytes.
+func ExtractSelectors(code []byte) map[[4]byte]struct{} {
+	selectors := make(map[[4]byte]struct{})
+
+	for pc := 0; pc < len(code); pc++ {
+		op := code[pc]
+		if op < opPUSH1 || op > opPUSH32 {
+			continue
+		}
+
+		size := int(op-opPUSH1) + 1
+		if op <= opPUSH4 && pc+size < len(code) && comparedWithEQ(code, pc+size+1) {
+			var selector [4]byte
+			copy(selector[4-size:], code[pc+1:pc+1+size])
+			selectors[selector] = struct{}{}
+		}
+
+		pc += size
+	}
+
+	return selectors
+}
+
+// HasDelegateCall reports whether code contains a DELEGATECALL instruction,
+// skipping PUSH immediates so constant bytes equal to 0xf4 do not count.
+func HasDelegateCall(code []byte) bool {
+	for pc := 0; pc < len(code); pc++ {
+		op := code[pc]
+		if op == opDELEGATECALL {
+			return true
+		}
+		if op >= opPUSH1 && op <= opPUSH32 {
+			pc += int(op-opPUSH1) + 1
+		}
+	}
+	return false
+}
+
+// comparedWithEQ reports whether the instruction at pc is EQ, allowing up to two
+// DUP/SWAP instructions in between as emitted by solc and vyper dispatchers.
+func comparedWithEQ(code []byte, pc int) bool {
+	for i := 0; i < 3 && pc < len(code); i, pc = i+1, pc+1 {
+		switch op := code[pc]; {
+		case op == opEQ:
+			return true
+		case op == opGT || op == opLT:
+			return false
+		case op >= opDUP1 && op <= opSWAP16:
+			continue
+		default:
+			return false
+		}
+	}
+	return false
+}
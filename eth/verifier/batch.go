@@ -0,0 +1,373 @@
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+type BatchOptions struct {
+	// Workers bounds the number of concurrent verifications. Defaults to
+	// runtime.NumCPU().
+	Workers int
+}
+
+type BatchResult struct {
+	Result *VerifyResult
+	Err    error
+}
+
+// VerifyBatch verifies reqs concurrently and returns one result per request,
+// in the same order. Typed-data requests sharing a type schema reuse its
+// precomputed type hashes, and those sharing a domain its separator.
+func VerifyBatch(ctx context.Context, reqs []*VerifyRequest, opts *BatchOptions) []*BatchResult {
+	workers := runtime.NumCPU()
+	if opts != nil && opts.Workers > 0 {
+		workers = opts.Workers
+	}
+
+	results := make([]*BatchResult, len(reqs))
+	hashers := newBatchHashers(reqs)
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indexes {
+				results[idx] = verifyBatchItem(idx, reqs[idx], hashers)
+			}
+		}()
+	}
+
+	for idx := range reqs {
+		if ctx.Err() != nil {
+			results[idx] = &BatchResult{Err: ctx.Err()}
+			continue
+		}
+		indexes <- idx
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+func verifyBatchItem(idx int, req *VerifyRequest, hashers *batchHashers) *BatchResult {
+	if req == nil {
+		return &BatchResult{Err: ErrPayloadIsEmpty}
+	}
+	if !common.IsHexAddress(req.ExpectedAddr) {
+		return &BatchResult{Err: ErrInvalidAddress}
+	}
+
+	hash, err := hashers.hash(idx, req)
+	if err != nil {
+		return &BatchResult{Err: err}
+	}
+
+	sig, err := decodeSignature(req.Signature)
+	if err != nil {
+		return &BatchResult{Err: err}
+	}
+
+	recovered, err := recoverAddress(hash, sig)
+	if err != nil {
+		return &BatchResult{Err: err}
+	}
+
+	result := &VerifyResult{
+		Signer: recovered.Hex(),
+		Hash:   hexutil.Encode(hash),
+	}
+
+	if recovered != common.HexToAddress(req.ExpectedAddr) {
+		return &BatchResult{Result: result, Err: ErrInvalidSignature}
+	}
+
+	return &BatchResult{Result: result}
+}
+
+// batchHashers holds what a batch shares between typed-data requests: per
+// type schema the type hashes, and per domain the separator. It is filled
+// before the workers start and only read afterwards.
+type batchHashers struct {
+	// items holds, by request index, the schema and separator of each
+	// typed-data request; nil for other requests.
+	items []*batchItem
+	// refs resolves a Types map, as held by a request, to its schema without
+	// re-encoding it; schemas is keyed by the canonical encoding of the types.
+	refs    map[typesRef]*batchSchema
+	schemas map[string]*batchSchema
+}
+
+// typesRef identifies a Types map by identity. domain records the shape of
+// the derived EIP712Domain type when the map does not define one.
+type typesRef struct {
+	types  uintptr
+	domain string
+}
+
+type batchSchema struct {
+	typedData  apitypes.TypedData
+	typeHashes map[string][]byte
+	// separators is keyed by domainKey.
+	separators map[string]batchSeparator
+}
+
+type batchItem struct {
+	schema    *batchSchema
+	separator batchSeparator
+}
+
+type batchSeparator struct {
+	hash []byte
+	err  error
+}
+
+func newBatchHashers(reqs []*VerifyRequest) *batchHashers {
+	h := &batchHashers{
+		items:   make([]*batchItem, len(reqs)),
+		refs:    make(map[typesRef]*batchSchema),
+		schemas: make(map[string]*batchSchema),
+	}
+
+	for idx, req := range reqs {
+		typedData, ok := typedDataPayload(req)
+		if !ok {
+			continue
+		}
+
+		ref := refOf(typedData)
+		schema, ok := h.refs[ref]
+		if !ok {
+			schema = h.schema(typedData)
+			h.refs[ref] = schema
+		}
+
+		key := domainKey(typedData.Domain)
+		separator, ok := schema.separators[key]
+		if !ok {
+			prepared := schema.typedData
+			prepared.Domain = typedData.Domain
+			separator.hash, separator.err = prepared.HashStruct("EIP712Domain", prepared.Domain.Map())
+			schema.separators[key] = separator
+		}
+
+		h.items[idx] = &batchItem{schema: schema, separator: separator}
+	}
+
+	return h
+}
+
+// schema returns the shared schema of the types of typedData, including the
+// EIP712Domain type HashTypedData would derive.
+func (h *batchHashers) schema(typedData *apitypes.TypedData) *batchSchema {
+	domainType, ok := typedData.Types["EIP712Domain"]
+	if !ok || len(domainType) == 0 {
+		domainType = DomainType(typedData.Domain)
+	}
+
+	names := make([]string, 0, len(typedData.Types)+1)
+	for name := range typedData.Types {
+		if name != "EIP712Domain" {
+			names = append(names, name)
+		}
+	}
+	names = append(names, "EIP712Domain")
+	sort.Strings(names)
+
+	fields := func(name string) []apitypes.Type {
+		if name == "EIP712Domain" {
+			return domainType
+		}
+		return typedData.Types[name]
+	}
+
+	var key strings.Builder
+	for _, name := range names {
+		key.WriteString(name)
+		key.WriteByte('(')
+		for _, field := range fields(name) {
+			key.WriteString(field.Type)
+			key.WriteByte(' ')
+			key.WriteString(field.Name)
+			key.WriteByte(',')
+		}
+		key.WriteByte(')')
+	}
+
+	if schema, ok := h.schemas[key.String()]; ok {
+		return schema
+	}
+
+	prepared := withDomainType(*typedData)
+	schema := &batchSchema{
+		typedData:  apitypes.TypedData{Types: prepared.Types},
+		typeHashes: make(map[string][]byte, len(names)),
+		separators: make(map[string]batchSeparator),
+	}
+	for _, name := range names {
+		schema.typeHashes[name] = prepared.TypeHash(name)
+	}
+	h.schemas[key.String()] = schema
+
+	return schema
+}
+
+// hash returns the digest of reqs[idx], the request at idx of the batch.
+func (h *batchHashers) hash(idx int, req *VerifyRequest) ([]byte, error) {
+	typedData, ok := typedDataPayload(req)
+	if !ok {
+		return hashPayload(req)
+	}
+
+	item := h.items[idx]
+	schema, separator := item.schema, item.separator
+	if separator.err != nil {
+		return nil, separator.err
+	}
+	if _, ok := schema.typeHashes[typedData.PrimaryType]; !ok {
+		return HashTypedData(*typedData)
+	}
+
+	typedDataHash, err := schema.hashStruct(typedData.PrimaryType, typedData.Message, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256([]byte("\x19\x01"), separator.hash, typedDataHash), nil
+}
+
+// hashStruct is apitypes.TypedData.HashStruct using the precomputed type
+// hashes. Primitive values are still encoded by apitypes.
+func (s *batchSchema) hashStruct(primaryType string, data map[string]any, depth int) ([]byte, error) {
+	fields := s.typedData.Types[primaryType]
+	if len(fields) < len(data) {
+		return nil, fmt.Errorf("there is extra data provided in the message (%d < %d)", len(fields), len(data))
+	}
+
+	encoded := make([]byte, 0, 32*(len(fields)+1))
+	encoded = append(encoded, s.typeHashes[primaryType]...)
+
+	for _, field := range fields {
+		value := data[field.Name]
+
+		var (
+			word []byte
+			err  error
+		)
+		switch {
+		case strings.HasSuffix(field.Type, "]"):
+			word, err = s.hashArray(value, field.Type, depth)
+		case s.typedData.Types[field.Type] != nil:
+			mapValue, ok := value.(map[string]any)
+			if !ok {
+				return nil, dataMismatchError(field.Type, value)
+			}
+			word, err = s.hashStruct(field.Type, mapValue, depth+1)
+		default:
+			word, err = s.typedData.EncodePrimitiveValue(field.Type, value, depth)
+		}
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, word...)
+	}
+
+	return crypto.Keccak256(encoded), nil
+}
+
+func (s *batchSchema) hashArray(value any, arrayType string, depth int) ([]byte, error) {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice {
+		return nil, dataMismatchError(arrayType, value)
+	}
+
+	elemType, _, _ := strings.Cut(arrayType, "[")
+	encoded := make([]byte, 0, 32*rv.Len())
+
+	for i := 0; i < rv.Len(); i++ {
+		item := rv.Index(i).Interface()
+
+		var (
+			word []byte
+			err  error
+		)
+		kind := reflect.TypeOf(item).Kind()
+		switch {
+		case (kind == reflect.Slice || kind == reflect.Array) && reflect.TypeOf(item).Elem().Kind() == reflect.Uint8:
+			word, err = s.typedData.EncodePrimitiveValue(elemType, item, depth+1)
+		case kind == reflect.Slice || kind == reflect.Array:
+			word, err = s.hashArray(item, elemType, depth+1)
+		case s.typedData.Types[elemType] != nil:
+			mapValue, ok := item.(map[string]any)
+			if !ok {
+				return nil, dataMismatchError(elemType, item)
+			}
+			word, err = s.hashStruct(elemType, mapValue, depth+1)
+		default:
+			word, err = s.typedData.EncodePrimitiveValue(elemType, item, depth)
+		}
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, word...)
+	}
+
+	return crypto.Keccak256(encoded), nil
+}
+
+func dataMismatchError(encType string, value any) error {
+	return fmt.Errorf("provided data '%v' doesn't match type '%s'", value, encType)
+}
+
+func typedDataPayload(req *VerifyRequest) (*apitypes.TypedData, bool) {
+	if req == nil || req.SignatureType != SignatureTypedData {
+		return nil, false
+	}
+
+	switch v := req.Payload.(type) {
+	case apitypes.TypedData:
+		return &v, true
+	case *apitypes.TypedData:
+		return v, v != nil
+	default:
+		return nil, false
+	}
+}
+
+// refOf returns the identity of the types typedData is hashed with.
+func refOf(typedData *apitypes.TypedData) typesRef {
+	ref := typesRef{types: reflect.ValueOf(typedData.Types).Pointer()}
+	if len(typedData.Types["EIP712Domain"]) == 0 {
+		for _, field := range DomainType(typedData.Domain) {
+			ref.domain += field.Name + ","
+		}
+	}
+	return ref
+}
+
+// domainKey identifies a domain by its values. Requests are only compared
+// within one schema, whose EIP712Domain type is fixed.
+func domainKey(d apitypes.TypedDataDomain) string {
+	chainID := ""
+	if d.ChainId != nil {
+		chainID = (*big.Int)(d.ChainId).String()
+	}
+
+	return d.Name + "\x00" + d.Version + "\x00" + chainID + "\x00" +
+		strings.ToLower(d.VerifyingContract) + "\x00" + strings.ToLower(d.Salt)
+}
@@ -0,0 +1,277 @@
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const benchmarkClaims = 1000
+
+// signedClaims builds n signed airdrop claims. Every request gets its own
+// Types map, as when claims are decoded from JSON one by one.
+func signedClaims(tb testing.TB, n int) []*VerifyRequest {
+	tb.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	account := crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	domain := apitypes.TypedDataDomain{
+		Name:              "Airdrop",
+		Version:           "1",
+		ChainId:           math.NewHexOrDecimal256(1),
+		VerifyingContract: "0x0000000000000000000000000000000000000001",
+	}
+
+	reqs := make([]*VerifyRequest, n)
+	for i := range reqs {
+		typedData := apitypes.TypedData{
+			Types: apitypes.Types{
+				"Claim": []apitypes.Type{
+					{Name: "account", Type: "address"},
+					{Name: "amount", Type: "uint256"},
+					{Name: "deadline", Type: "uint256"},
+				},
+			},
+			PrimaryType: "Claim",
+			Domain:      domain,
+			Message: apitypes.TypedDataMessage{
+				"account":  account,
+				"amount":   fmt.Sprint(i + 1),
+				"deadline": "1900000000",
+			},
+		}
+
+		reqs[i] = signTypedData(tb, key, typedData)
+	}
+
+	return reqs
+}
+
+// signTypedData signs typedData with key and expects the key's address.
+func signTypedData(tb testing.TB, key *ecdsa.PrivateKey, typedData apitypes.TypedData) *VerifyRequest {
+	tb.Helper()
+
+	hash, err := HashTypedData(typedData)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return &VerifyRequest{
+		SignatureType: SignatureTypedData,
+		Payload:       typedData,
+		Signature:     hexutil.Encode(sig),
+		ExpectedAddr:  crypto.PubkeyToAddress(key.PublicKey).Hex(),
+	}
+}
+
+func TestVerifyBatchOrder(t *testing.T) {
+	reqs := signedClaims(t, 20)
+
+	results := VerifyBatch(context.Background(), reqs, &BatchOptions{Workers: 4})
+	if len(results) != len(reqs) {
+		t.Fatalf("got %d results, want %d", len(results), len(reqs))
+	}
+
+	for i, result := range results {
+		if result.Err != nil {
+			t.Fatalf("result %d: %v", i, result.Err)
+		}
+
+		want, err := HashTypedData(reqs[i].Payload.(apitypes.TypedData))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Result.Hash != hexutil.Encode(want) {
+			t.Errorf("result %d has hash %s, want %s", i, result.Result.Hash, hexutil.Encode(want))
+		}
+	}
+}
+
+func TestVerifyBatchBadSignature(t *testing.T) {
+	reqs := signedClaims(t, 2)
+	signer := reqs[1].ExpectedAddr
+
+	reqs[1].ExpectedAddr = "0x0000000000000000000000000000000000000002"
+
+	results := VerifyBatch(context.Background(), reqs, nil)
+	if results[0].Err != nil {
+		t.Fatalf("valid request failed: %v", results[0].Err)
+	}
+	if !errors.Is(results[1].Err, ErrInvalidSignature) {
+		t.Fatalf("got %v, want ErrInvalidSignature", results[1].Err)
+	}
+	if results[1].Result == nil || results[1].Result.Signer != signer {
+		t.Fatalf("got result %+v, want signer %s", results[1].Result, signer)
+	}
+}
+
+func TestVerifyBatchInvalidRequests(t *testing.T) {
+	reqs := signedClaims(t, 3)
+	reqs[0].ExpectedAddr = "not an address"
+	reqs[1] = nil
+
+	results := VerifyBatch(context.Background(), reqs, nil)
+	if !errors.Is(results[0].Err, ErrInvalidAddress) {
+		t.Errorf("invalid ExpectedAddr: got %v, want ErrInvalidAddress", results[0].Err)
+	}
+	if !errors.Is(results[1].Err, ErrPayloadIsEmpty) {
+		t.Errorf("nil request: got %v, want ErrPayloadIsEmpty", results[1].Err)
+	}
+	if results[2].Err != nil {
+		t.Errorf("valid request failed: %v", results[2].Err)
+	}
+}
+
+func TestVerifyBatchCancelled(t *testing.T) {
+	reqs := signedClaims(t, 5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for i, result := range VerifyBatch(ctx, reqs, nil) {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("result %d: got %v, want context.Canceled", i, result.Err)
+		}
+	}
+}
+
+// TestVerifyBatchHashMatches checks the cached batch encoding against
+// HashTypedData for nested structs, arrays and a caller supplied domain type.
+func TestVerifyBatchHashMatches(t *testing.T) {
+	types := apitypes.Types{
+		"EIP712Domain": []apitypes.Type{
+			{Name: "name", Type: "string"},
+			{Name: "chainId", Type: "uint256"},
+		},
+		"Mail": []apitypes.Type{
+			{Name: "from", Type: "Person"},
+			{Name: "to", Type: "Person[]"},
+			{Name: "contents", Type: "string"},
+			{Name: "tags", Type: "bytes32[]"},
+			{Name: "grid", Type: "uint8[][]"},
+		},
+		"Person": []apitypes.Type{
+			{Name: "name", Type: "string"},
+			{Name: "wallet", Type: "address"},
+		},
+	}
+
+	person := func(name string, wallet string) map[string]any {
+		return map[string]any{"name": name, "wallet": wallet}
+	}
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var reqs []*VerifyRequest
+	for i := 0; i < 3; i++ {
+		typedData := apitypes.TypedData{
+			Types:       types,
+			PrimaryType: "Mail",
+			Domain: apitypes.TypedDataDomain{
+				Name:    "Mail",
+				ChainId: math.NewHexOrDecimal256(int64(i%2 + 1)),
+			},
+			Message: apitypes.TypedDataMessage{
+				"from": person("Cow", "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"),
+				"to": []any{
+					person("Bob", "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"),
+					person(fmt.Sprint("Carol ", i), "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"),
+				},
+				"contents": "Hello",
+				"tags":     []any{"0x" + fmt.Sprintf("%064x", i)},
+				"grid":     []any{[]any{"1", "2"}, []any{"3"}},
+			},
+		}
+		reqs = append(reqs, signTypedData(t, key, typedData))
+	}
+
+	hashers := newBatchHashers(reqs)
+	for i, req := range reqs {
+		got, err := hashers.hash(i, req)
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		want, err := HashTypedData(req.Payload.(apitypes.TypedData))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("request %d: got %x, want %x", i, got, want)
+		}
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	reqs := signedClaims(b, benchmarkClaims)
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		for _, req := range reqs {
+			if err := Verify(req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkVerifyBatch(b *testing.B) {
+	reqs := signedClaims(b, benchmarkClaims)
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		for _, result := range VerifyBatch(context.Background(), reqs, nil) {
+			if result.Err != nil {
+				b.Fatal(result.Err)
+			}
+		}
+	}
+}
+
+// The hashing benchmarks leave out signature recovery, which dominates
+// Verify, to measure the cost the batch caches save.
+func BenchmarkHashTypedData(b *testing.B) {
+	reqs := signedClaims(b, benchmarkClaims)
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		for _, req := range reqs {
+			if _, err := HashTypedData(req.Payload.(apitypes.TypedData)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkHashTypedDataBatch(b *testing.B) {
+	reqs := signedClaims(b, benchmarkClaims)
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		hashers := newBatchHashers(reqs)
+		for idx, req := range reqs {
+			if _, err := hashers.hash(idx, req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/dtome123/go-bcwe3/eth/constants"
+	"github.com/dtome123/go-bcwe3/eth/provider"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	ErrInvalidERC6492Signature = errors.New("invalid ERC-6492 signature")
+)
+
+// Verifier verifies signatures of both EOAs and smart contract wallets.
+type Verifier interface {
+	// Verify checks req against the code deployed at req.ExpectedAddr.
+	// Accounts with code are verified through EIP-1271 isValidSignature,
+	// ERC-6492 wrapped signatures of undeployed wallets are verified by
+	// simulating the factory deployment, anything else falls back to ecrecover.
+	Verify(ctx context.Context, req *VerifyRequest) error
+}
+
+type contractVerifier struct {
+	provider provider.Provider
+	abi      abi.ABI
+}
+
+func NewVerifier(provider provider.Provider) Verifier {
+	parsedABI, _ := abi.JSON(strings.NewReader(constants.ERC1271ABI))
+
+	return &contractVerifier{
+		provider: provider,
+		abi:      parsedABI,
+	}
+}
+
+// erc6492Validator is creation code that, when run through eth_call, calls
+// the factory with its calldata (ignoring failures), then staticcalls the
+// wallet and returns (first 32 bytes of the result, success flag).
+//
+// The code expects the following data appended to it:
+//
+//	[factory:32][wallet:32][len(factoryCalldata):32][len(validateCalldata):32]
+//	[factoryCalldata][validateCalldata]
+var erc6492Validator = common.FromHex("0x" +
+	// CODECOPY(0, codeLen, CODESIZE-codeLen)
+	"6039" + "80" + "38" + "03" + "90" + "6000" + "39" +
+	// POP(CALL(GAS, factory, 0, 128, factoryLen, 0, 0))
+	"6000" + "6000" + "604051" + "6080" + "6000" + "600051" + "5a" + "f1" + "50" +
+	// MSTORE(0, 0); STATICCALL(GAS, wallet, 128+factoryLen, validateLen, 0, 32)
+	"6020" + "6000" + "606051" + "604051608001" + "602051" + "6000600052" + "5a" + "fa" +
+	// MSTORE(32, success); RETURN(0, 64)
+	"602052" + "6040" + "6000" + "f3",
+)
+
+func (v *contractVerifier) Verify(ctx context.Context, req *VerifyRequest) error {
+	if !common.IsHexAddress(req.ExpectedAddr) {
+		return ErrInvalidAddress
+	}
+
+	sig, err := decodeSignature(req.Signature)
+	if err != nil {
+		return err
+	}
+
+	hash, err := hashPayload(req)
+	if err != nil {
+		return err
+	}
+
+	wallet := common.HexToAddress(req.ExpectedAddr)
+
+	code, err := v.provider.CodeAt(ctx, wallet.Hex(), nil)
+	if err != nil {
+		return err
+	}
+
+	if isERC6492(sig) {
+		factory, factoryCalldata, innerSig, err := v.unwrapERC6492(sig)
+		if err != nil {
+			return err
+		}
+
+		if len(code) == 0 {
+			return v.verifyCounterfactual(ctx, factory, factoryCalldata, wallet, hash, innerSig)
+		}
+
+		sig = innerSig
+	}
+
+	if len(code) > 0 {
+		return v.verifyERC1271(ctx, wallet, hash, sig)
+	}
+
+	recovered, err := recoverAddress(hash, sig)
+	if err != nil {
+		return err
+	}
+
+	if recovered != wallet {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (v *contractVerifier) verifyERC1271(ctx context.Context, wallet common.Address, hash []byte, sig []byte) error {
+	input, err := v.packIsValidSignature(hash, sig)
+	if err != nil {
+		return err
+	}
+
+	result, err := v.provider.CallContract(ctx, ethereum.CallMsg{
+		To:   &wallet,
+		Data: input,
+	}, nil)
+	if err != nil {
+		return asInvalidSignature(err)
+	}
+
+	if !hasMagicValue(result) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (v *contractVerifier) verifyCounterfactual(
+	ctx context.Context,
+	factory common.Address,
+	factoryCalldata []byte,
+	wallet common.Address,
+	hash []byte,
+	sig []byte,
+) error {
+	validateCalldata, err := v.packIsValidSignature(hash, sig)
+	if err != nil {
+		return err
+	}
+
+	data := make([]byte, 0, len(erc6492Validator)+128+len(factoryCalldata)+len(validateCalldata))
+	data = append(data, erc6492Validator...)
+	data = append(data, common.LeftPadBytes(factory.Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(wallet.Bytes(), 32)...)
+	data = append(data, uint256Bytes(len(factoryCalldata))...)
+	data = append(data, uint256Bytes(len(validateCalldata))...)
+	data = append(data, factoryCalldata...)
+	data = append(data, validateCalldata...)
+
+	result, err := v.provider.CallContract(ctx, ethereum.CallMsg{Data: data}, nil)
+	if err != nil {
+		return asInvalidSignature(err)
+	}
+
+	if len(result) != 64 || new(big.Int).SetBytes(result[32:]).Sign() == 0 {
+		return ErrInvalidSignature
+	}
+
+	if !hasMagicValue(result[:32]) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (v *contractVerifier) packIsValidSignature(hash []byte, sig []byte) ([]byte, error) {
+	var digest [32]byte
+	copy(digest[:], hash)
+
+	return v.abi.Pack("isValidSignature", digest, sig)
+}
+
+func (v *contractVerifier) unwrapERC6492(sig []byte) (common.Address, []byte, []byte, error) {
+	arguments := abi.Arguments{
+		{Type: mustType("address")},
+		{Type: mustType("bytes")},
+		{Type: mustType("bytes")},
+	}
+
+	values, err := arguments.Unpack(sig[:len(sig)-32])
+	if err != nil || len(values) != 3 {
+		return common.Address{}, nil, nil, ErrInvalidERC6492Signature
+	}
+
+	factory, ok1 := values[0].(common.Address)
+	factoryCalldata, ok2 := values[1].([]byte)
+	innerSig, ok3 := values[2].([]byte)
+	if !ok1 || !ok2 || !ok3 {
+		return common.Address{}, nil, nil, ErrInvalidERC6492Signature
+	}
+
+	return factory, factoryCalldata, innerSig, nil
+}
+
+func isERC6492(sig []byte) bool {
+	return len(sig) > 32 && bytes.Equal(sig[len(sig)-32:], constants.ERC6492MagicSuffix[:])
+}
+
+func hasMagicValue(result []byte) bool {
+	return len(result) >= 4 && bytes.Equal(result[:4], constants.ERC1271MagicValue[:])
+}
+
+// asInvalidSignature maps a reverted call to ErrInvalidSignature, since
+// many wallets revert instead of returning a non-magic value.
+func asInvalidSignature(err error) error {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		return ErrInvalidSignature
+	}
+
+	return err
+}
+
+func uint256Bytes(n int) []byte {
+	buf := make([]byte, 32)
+	binary.BigEndian.PutUint64(buf[24:], uint64(n))
+	return buf
+}
+
+func mustType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
@@ -18,6 +19,7 @@ var (
 	ErrRecoverPublicKey       = errors.New("failed to recover public key")
 	ErrInvalidPayloadType     = errors.New("invalid payload type")
 	ErrPayloadIsEmpty         = errors.New("payload is empty")
+	ErrInvalidAddress         = errors.New("invalid address")
 )
 
 type SignatureType int
@@ -25,8 +27,16 @@ type SignatureType int
 const (
 	SignaturePersonalSign SignatureType = iota
 	SignatureTypedData
+	// SignatureIntendedValidator is EIP-191 version 0x00 data with an
+	// intended validator; the payload is an IntendedValidatorPayload.
+	SignatureIntendedValidator
 )
 
+type IntendedValidatorPayload struct {
+	Validator string
+	Data      []byte
+}
+
 type VerifyRequest struct {
 	SignatureType SignatureType
 	Payload       any
@@ -34,113 +44,219 @@ type VerifyRequest struct {
 	ExpectedAddr  string
 }
 
-func Verify(req *VerifyRequest) error {
-	switch req.SignatureType {
-	case SignaturePersonalSign:
-		if msg, ok := req.Payload.(string); ok {
-			return verifyLegacySignature(msg, req.Signature, req.ExpectedAddr)
-		}
-
-		return ErrInvalidPayloadType
-	case SignatureTypedData:
+type VerifyResult struct {
+	// Signer is the checksummed address recovered from the signature.
+	Signer string `json:"signer"`
+	// Hash is the digest that was signed: the EIP-191 message hash or the
+	// EIP-712 typed-data hash.
+	Hash string `json:"hash"`
+}
 
-		switch v := any(req.Payload).(type) {
-		case apitypes.TypedData:
-			return verifyEIP712Signature(v, req.Signature, req.ExpectedAddr)
-		case *apitypes.TypedData:
-			if v == nil {
-				return ErrPayloadIsEmpty
-			}
-			return verifyEIP712Signature(*v, req.Signature, req.ExpectedAddr)
-		default:
-			return ErrInvalidPayloadType
-		}
+func Verify(req *VerifyRequest) error {
+	_, err := VerifyWithResult(req)
+	return err
+}
 
-	default:
-		return ErrUnsupportedType
+// VerifyWithResult verifies req and returns the recovered signer and signed
+// digest. On ErrInvalidSignature the result is still returned so callers can
+// log who actually signed.
+func VerifyWithResult(req *VerifyRequest) (*VerifyResult, error) {
+	if !common.IsHexAddress(req.ExpectedAddr) {
+		return nil, ErrInvalidAddress
 	}
-}
 
-func verifyLegacySignature(message string, sigHex string, address string) error {
-	// Decode hex signature
-	sigHex = strings.TrimPrefix(sigHex, "0x")
-	sig, err := hex.DecodeString(sigHex)
+	result, err := Recover(req)
 	if err != nil {
-		return ErrInvalidSignature
+		return nil, err
 	}
 
-	if len(sig) != 65 {
-		return ErrInvalidSignatureLength
+	if common.HexToAddress(result.Signer) != common.HexToAddress(req.ExpectedAddr) {
+		return result, ErrInvalidSignature
 	}
 
-	// Adjust recovery ID if necessary
-	if sig[64] >= 27 {
-		sig[64] -= 27
+	return result, nil
+}
+
+// Recover returns the address that signed req. ExpectedAddr is ignored.
+func Recover(req *VerifyRequest) (*VerifyResult, error) {
+	hash, err := hashPayload(req)
+	if err != nil {
+		return nil, err
 	}
 
-	// Hash message with Ethereum prefix
-	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(message))
-	msgHash := crypto.Keccak256([]byte(prefix + message))
+	sig, err := decodeSignature(req.Signature)
+	if err != nil {
+		return nil, err
+	}
 
-	// Recover public key
-	pubKey, err := crypto.SigToPub(msgHash, sig)
+	recovered, err := recoverAddress(hash, sig)
 	if err != nil {
-		return ErrRecoverPublicKey
+		return nil, err
 	}
 
-	recoveredAddr := crypto.PubkeyToAddress(*pubKey)
-	normalizedExpected := strings.ToLower(common.HexToAddress(address).Hex())
-	normalizedActual := strings.ToLower(recoveredAddr.Hex())
+	return &VerifyResult{
+		Signer: recovered.Hex(),
+		Hash:   hexutil.Encode(hash),
+	}, nil
+}
 
-	if normalizedExpected != normalizedActual {
-		return ErrInvalidSignature
+// DomainType lists the EIP712Domain fields present in domain, in canonical order.
+func DomainType(domain apitypes.TypedDataDomain) []apitypes.Type {
+	var fields []apitypes.Type
+	if domain.Name != "" {
+		fields = append(fields, apitypes.Type{Name: "name", Type: "string"})
 	}
-
-	return nil
+	if domain.Version != "" {
+		fields = append(fields, apitypes.Type{Name: "version", Type: "string"})
+	}
+	if domain.ChainId != nil {
+		fields = append(fields, apitypes.Type{Name: "chainId", Type: "uint256"})
+	}
+	if domain.VerifyingContract != "" {
+		fields = append(fields, apitypes.Type{Name: "verifyingContract", Type: "address"})
+	}
+	if domain.Salt != "" {
+		fields = append(fields, apitypes.Type{Name: "salt", Type: "bytes32"})
+	}
+	return fields
 }
 
-func verifyEIP712Signature(typedData apitypes.TypedData, sigHex string, expectedAddrHex string) error {
+// HashPersonalMessage returns the EIP-191 personal_sign digest of message.
+func HashPersonalMessage(message string) []byte {
+	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(message))
+	return crypto.Keccak256([]byte(prefix + message))
+}
 
-	typedData.Types["EIP712Domain"] = []apitypes.Type{
-		{Name: "name", Type: "string"},
-		{Name: "version", Type: "string"},
-		{Name: "chainId", Type: "uint256"},
-		{Name: "verifyingContract", Type: "address"},
-	}
+// HashTypedData returns the EIP-712 digest of typedData. An EIP712Domain type
+// supplied by the caller is used as is; otherwise it is derived from the
+// domain fields that are set. The caller's Types map is never modified.
+func HashTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	typedData = withDomainType(typedData)
 
 	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	typedDataHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// EIP-712 hash
-	data := crypto.Keccak256(
+	return crypto.Keccak256(
 		[]byte("\x19\x01"),
 		domainSeparator,
 		typedDataHash,
-	)
+	), nil
+}
 
-	sig, err := hex.DecodeString(strings.TrimPrefix(sigHex, "0x"))
-	if err != nil {
-		return err
+// withDomainType returns typedData with an EIP712Domain type derived from the
+// domain when the caller did not supply one. The caller's Types map is not modified.
+func withDomainType(typedData apitypes.TypedData) apitypes.TypedData {
+	if len(typedData.Types["EIP712Domain"]) > 0 {
+		return typedData
+	}
+
+	types := make(apitypes.Types, len(typedData.Types)+1)
+	for name, fields := range typedData.Types {
+		types[name] = fields
+	}
+	types["EIP712Domain"] = DomainType(typedData.Domain)
+	typedData.Types = types
+
+	return typedData
+}
+
+// HashIntendedValidator returns the EIP-191 version 0x00 digest of data
+// addressed to validator.
+func HashIntendedValidator(validator string, data []byte) ([]byte, error) {
+	if !common.IsHexAddress(validator) {
+		return nil, ErrInvalidAddress
 	}
-	if sig[64] >= 27 {
-		sig[64] -= 27
+
+	return crypto.Keccak256(
+		[]byte{0x19, 0x00},
+		common.HexToAddress(validator).Bytes(),
+		data,
+	), nil
+}
+
+// hashPayload returns the digest that was signed for req.
+func hashPayload(req *VerifyRequest) ([]byte, error) {
+	switch req.SignatureType {
+	case SignaturePersonalSign:
+		if msg, ok := req.Payload.(string); ok {
+			return HashPersonalMessage(msg), nil
+		}
+
+		return nil, ErrInvalidPayloadType
+	case SignatureTypedData:
+
+		switch v := any(req.Payload).(type) {
+		case apitypes.TypedData:
+			return HashTypedData(v)
+		case *apitypes.TypedData:
+			if v == nil {
+				return nil, ErrPayloadIsEmpty
+			}
+			return HashTypedData(*v)
+		default:
+			return nil, ErrInvalidPayloadType
+		}
+
+	case SignatureIntendedValidator:
+
+		switch v := any(req.Payload).(type) {
+		case IntendedValidatorPayload:
+			return HashIntendedValidator(v.Validator, v.Data)
+		case *IntendedValidatorPayload:
+			if v == nil {
+				return nil, ErrPayloadIsEmpty
+			}
+			return HashIntendedValidator(v.Validator, v.Data)
+		default:
+			return nil, ErrInvalidPayloadType
+		}
+
+	default:
+		return nil, ErrUnsupportedType
 	}
+}
 
-	pubKey, err := crypto.SigToPub(data, sig)
+func decodeSignature(sigHex string) ([]byte, error) {
+	sig, err := hex.DecodeString(strings.TrimPrefix(sigHex, "0x"))
 	if err != nil {
-		return err
+		return nil, ErrInvalidSignature
+	}
+	return sig, nil
+}
+
+// recoverAddress recovers the signer of hash from a 65-byte [R || S || V]
+// signature, with V in {0, 1, 27, 28}, or a 64-byte EIP-2098 compact
+// [R || yParity+S] signature.
+func recoverAddress(hash []byte, sig []byte) (common.Address, error) {
+	var normalized []byte
+
+	switch len(sig) {
+	case 65:
+		normalized = common.CopyBytes(sig)
+		if normalized[64] >= 27 {
+			normalized[64] -= 27
+		}
+	case 64:
+		normalized = make([]byte, 65)
+		copy(normalized, sig)
+		normalized[64] = sig[32] >> 7
+		normalized[32] &= 0x7f
+	default:
+		return common.Address{}, ErrInvalidSignatureLength
 	}
 
-	if crypto.PubkeyToAddress(*pubKey).Hex() != expectedAddrHex {
-		return ErrInvalidSignature
+	pubKey, err := crypto.SigToPub(hash, normalized)
+	if err != nil {
+		return common.Address{}, ErrRecoverPublicKey
 	}
 
-	return nil
+	return crypto.PubkeyToAddress(*pubKey), nil
 }